@@ -1002,6 +1002,84 @@ func (client *Client) GetContextFileUploadUrl(request *GetContextFileUploadUrlRe
 	return _result, _err
 }
 
+// Summary:
+//
+// # Initialize browser
+//
+// @param tmpReq - InitBrowserRequest
+//
+// @param runtime - runtime options for this request RuntimeOptions
+//
+// @return InitBrowserResponse
+func (client *Client) InitBrowserWithOptions(tmpReq *InitBrowserRequest, runtime *dara.RuntimeOptions) (_result *InitBrowserResponse, _err error) {
+	_err = tmpReq.Validate()
+	if _err != nil {
+		return _result, _err
+	}
+	request := &InitBrowserShrinkRequest{}
+	openapiutil.Convert(tmpReq, request)
+	if !dara.IsNil(tmpReq.BrowserOption) {
+		request.BrowserOptionShrink = openapiutil.ArrayToStringWithSpecifiedStyle(tmpReq.BrowserOption, dara.String("BrowserOption"), dara.String("json"))
+	}
+
+	body := map[string]interface{}{}
+	if !dara.IsNil(request.Authorization) {
+		body["Authorization"] = request.Authorization
+	}
+
+	if !dara.IsNil(request.BrowserOptionShrink) {
+		body["BrowserOption"] = request.BrowserOptionShrink
+	}
+
+	if !dara.IsNil(request.PersistentPath) {
+		body["PersistentPath"] = request.PersistentPath
+	}
+
+	if !dara.IsNil(request.SessionId) {
+		body["SessionId"] = request.SessionId
+	}
+
+	req := &openapiutil.OpenApiRequest{
+		Body: openapiutil.ParseToMap(body),
+	}
+	params := &openapiutil.Params{
+		Action:      dara.String("InitBrowser"),
+		Version:     dara.String("2025-05-06"),
+		Protocol:    dara.String("HTTPS"),
+		Pathname:    dara.String("/"),
+		Method:      dara.String("POST"),
+		AuthType:    dara.String("Anonymous"),
+		Style:       dara.String("RPC"),
+		ReqBodyType: dara.String("formData"),
+		BodyType:    dara.String("json"),
+	}
+	_result = &InitBrowserResponse{}
+	_body, _err := client.CallApi(params, req, runtime)
+	if _err != nil {
+		return _result, _err
+	}
+	_err = dara.Convert(_body, &_result)
+	return _result, _err
+}
+
+// Summary:
+//
+// # Initialize browser
+//
+// @param request - InitBrowserRequest
+//
+// @return InitBrowserResponse
+func (client *Client) InitBrowser(request *InitBrowserRequest) (_result *InitBrowserResponse, _err error) {
+	runtime := &dara.RuntimeOptions{}
+	_result = &InitBrowserResponse{}
+	_body, _err := client.InitBrowserWithOptions(request, runtime)
+	if _err != nil {
+		return _result, _err
+	}
+	_result = _body
+	return _result, _err
+}
+
 // Summary:
 //
 // # Get context list
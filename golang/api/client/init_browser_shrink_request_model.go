@@ -0,0 +1,75 @@
+// This file is auto-generated, don't edit it. Thanks.
+package client
+
+import (
+	"github.com/alibabacloud-go/tea/dara"
+)
+
+type iInitBrowserShrinkRequest interface {
+	dara.Model
+	String() string
+	GoString() string
+	SetAuthorization(v string) *InitBrowserShrinkRequest
+	GetAuthorization() *string
+	SetBrowserOptionShrink(v string) *InitBrowserShrinkRequest
+	GetBrowserOptionShrink() *string
+	SetPersistentPath(v string) *InitBrowserShrinkRequest
+	GetPersistentPath() *string
+	SetSessionId(v string) *InitBrowserShrinkRequest
+	GetSessionId() *string
+}
+
+type InitBrowserShrinkRequest struct {
+	Authorization       *string `json:"Authorization,omitempty" xml:"Authorization,omitempty"`
+	BrowserOptionShrink *string `json:"BrowserOption,omitempty" xml:"BrowserOption,omitempty"`
+	PersistentPath      *string `json:"PersistentPath,omitempty" xml:"PersistentPath,omitempty"`
+	SessionId           *string `json:"SessionId,omitempty" xml:"SessionId,omitempty"`
+}
+
+func (s InitBrowserShrinkRequest) String() string {
+	return dara.Prettify(s)
+}
+
+func (s InitBrowserShrinkRequest) GoString() string {
+	return s.String()
+}
+
+func (s *InitBrowserShrinkRequest) GetAuthorization() *string {
+	return s.Authorization
+}
+
+func (s *InitBrowserShrinkRequest) GetBrowserOptionShrink() *string {
+	return s.BrowserOptionShrink
+}
+
+func (s *InitBrowserShrinkRequest) GetPersistentPath() *string {
+	return s.PersistentPath
+}
+
+func (s *InitBrowserShrinkRequest) GetSessionId() *string {
+	return s.SessionId
+}
+
+func (s *InitBrowserShrinkRequest) SetAuthorization(v string) *InitBrowserShrinkRequest {
+	s.Authorization = &v
+	return s
+}
+
+func (s *InitBrowserShrinkRequest) SetBrowserOptionShrink(v string) *InitBrowserShrinkRequest {
+	s.BrowserOptionShrink = &v
+	return s
+}
+
+func (s *InitBrowserShrinkRequest) SetPersistentPath(v string) *InitBrowserShrinkRequest {
+	s.PersistentPath = &v
+	return s
+}
+
+func (s *InitBrowserShrinkRequest) SetSessionId(v string) *InitBrowserShrinkRequest {
+	s.SessionId = &v
+	return s
+}
+
+func (s *InitBrowserShrinkRequest) Validate() error {
+	return dara.Validate(s)
+}
@@ -1,10 +1,12 @@
 package agent
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/logger"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
 )
 
@@ -46,11 +48,18 @@ func NewAgent(session McpSession) *Agent {
 
 // ExecuteTask executes a specific task described in human language
 func (a *Agent) ExecuteTask(task string, maxTryTimes int) *ExecutionResult {
+	return a.ExecuteTaskWithContext(context.Background(), task, maxTryTimes)
+}
+
+// ExecuteTaskWithContext executes a task like ExecuteTask, but stops polling for completion
+// when ctx is cancelled or its deadline expires. The remote task is not terminated
+// automatically; call TerminateTask with the returned TaskID if it should be stopped.
+func (a *Agent) ExecuteTaskWithContext(ctx context.Context, task string, maxTryTimes int) *ExecutionResult {
 	args := map[string]interface{}{
 		"task": task,
 	}
 
-	result, err := a.Session.CallMcpTool("flux_execute_task", args)
+	result, err := models.CallMcpToolWithContext(ctx, a.Session, "flux_execute_task", args)
 	if err != nil {
 		return &ExecutionResult{
 			ApiResponse:  models.ApiResponse{RequestID: ""},
@@ -97,7 +106,7 @@ func (a *Agent) ExecuteTask(task string, maxTryTimes int) *ExecutionResult {
 	// Poll for task completion
 	triedTime := 0
 	for triedTime < maxTryTimes {
-		query := a.GetTaskStatus(taskID)
+		query := a.getTaskStatus(ctx, taskID)
 		if !query.Success {
 			return &ExecutionResult{
 				ApiResponse:  models.ApiResponse{RequestID: result.RequestID},
@@ -157,8 +166,18 @@ func (a *Agent) ExecuteTask(task string, maxTryTimes int) *ExecutionResult {
 			}
 		}
 
-		fmt.Printf("Task %s is still running, please wait for a while.\n", taskID)
-		time.Sleep(3 * time.Second)
+		logger.From(a.Session).Debug("Task is still running", logger.KeySessionID, a.Session.GetSessionId(), "task_id", taskID)
+		select {
+		case <-ctx.Done():
+			return &ExecutionResult{
+				ApiResponse:  models.ApiResponse{RequestID: result.RequestID},
+				Success:      false,
+				ErrorMessage: fmt.Sprintf("Task execution cancelled: %v", ctx.Err()),
+				TaskStatus:   "cancelled",
+				TaskID:       taskID,
+			}
+		case <-time.After(3 * time.Second):
+		}
 		triedTime++
 	}
 
@@ -173,11 +192,16 @@ func (a *Agent) ExecuteTask(task string, maxTryTimes int) *ExecutionResult {
 
 // GetTaskStatus gets the status of the task with the given task ID
 func (a *Agent) GetTaskStatus(taskID string) *QueryResult {
+	return a.getTaskStatus(context.Background(), taskID)
+}
+
+// getTaskStatus queries the task status, threading ctx through the MCP tool call
+func (a *Agent) getTaskStatus(ctx context.Context, taskID string) *QueryResult {
 	args := map[string]interface{}{
 		"task_id": taskID,
 	}
 
-	result, err := a.Session.CallMcpTool("flux_get_task_status", args)
+	result, err := models.CallMcpToolWithContext(ctx, a.Session, "flux_get_task_status", args)
 	if err != nil {
 		return &QueryResult{
 			ApiResponse:  models.ApiResponse{RequestID: ""},
@@ -203,7 +227,7 @@ func (a *Agent) GetTaskStatus(taskID string) *QueryResult {
 
 // TerminateTask terminates a task with a specified task ID
 func (a *Agent) TerminateTask(taskID string) *ExecutionResult {
-	fmt.Println("Terminating task")
+	logger.From(a.Session).Info("Terminating task", logger.KeySessionID, a.Session.GetSessionId(), "task_id", taskID)
 
 	args := map[string]interface{}{
 		"task_id": taskID,
@@ -1,16 +1,18 @@
 package agentbay
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
-	"strings"
 	"sync"
 	"time"
 
 	openapiutil "github.com/alibabacloud-go/darabonba-openapi/v2/utils"
+	"github.com/alibabacloud-go/tea/dara"
 	"github.com/alibabacloud-go/tea/tea"
 	mcp "github.com/aliyun/wuying-agentbay-sdk/golang/api/client"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/logger"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
 )
 
@@ -19,8 +21,10 @@ type Option func(*AgentBayConfig)
 
 // AgentBayConfig holds optional configuration for the AgentBay client.
 type AgentBayConfig struct {
-	cfg     *Config
-	envFile string
+	cfg         *Config
+	envFile     string
+	logger      logger.Logger
+	rateLimiter RateLimiter
 }
 
 // WithConfig returns an Option that sets the configuration for the AgentBay client.
@@ -43,6 +47,10 @@ type AgentBay struct {
 	Client   *mcp.Client
 	Sessions sync.Map
 	Context  *ContextService
+
+	logger      logger.Logger
+	retryPolicy *RetryPolicy
+	rateLimiter RateLimiter
 }
 
 // NewAgentBay creates a new AgentBay client.
@@ -75,6 +83,7 @@ func NewAgentBay(apiKey string, opts ...Option) (*AgentBay, error) {
 		Endpoint:       tea.String(config.Endpoint),
 		ReadTimeout:    tea.Int(config.TimeoutMs),
 		ConnectTimeout: tea.Int(config.TimeoutMs),
+		HttpClient:     NewHTTPClient(config.TimeoutMs),
 	}
 
 	client, err := mcp.NewClient(apiConfig)
@@ -84,9 +93,12 @@ func NewAgentBay(apiKey string, opts ...Option) (*AgentBay, error) {
 
 	// Create AgentBay instance
 	agentBay := &AgentBay{
-		APIKey:  apiKey,
-		Client:  client,
-		Context: nil, // Will be initialized after creation
+		APIKey:      apiKey,
+		Client:      client,
+		Context:     nil, // Will be initialized after creation
+		logger:      config_option.logger,
+		retryPolicy: config.Retry,
+		rateLimiter: config_option.rateLimiter,
 	}
 
 	// Initialize context service
@@ -101,9 +113,31 @@ func NewAgentBayWithDefaults(apiKey string) (*AgentBay, error) {
 	return NewAgentBay(apiKey, nil)
 }
 
+// GetRetryPolicy returns the retry policy applied to API calls, falling back to DefaultRetryPolicy()
+func (a *AgentBay) GetRetryPolicy() *RetryPolicy {
+	if a == nil || a.retryPolicy == nil {
+		return DefaultRetryPolicy()
+	}
+	return a.retryPolicy
+}
+
+// SetRetryPolicy replaces the retry policy applied to API calls made by this client and its sessions.
+// Passing nil restores DefaultRetryPolicy(); NoRetry() disables retries.
+func (a *AgentBay) SetRetryPolicy(p *RetryPolicy) {
+	a.retryPolicy = p
+}
+
 // Create creates a new session in the AgentBay cloud environment.
 // If params is nil, default parameters will be used.
 func (a *AgentBay) Create(params *CreateSessionParams) (*SessionResult, error) {
+	return a.CreateWithContext(context.Background(), params)
+}
+
+// CreateWithContext creates a new session in the AgentBay cloud environment, honouring the
+// cancellation and deadline of ctx for the API call and the context synchronization wait.
+// If ctx is done after the session has been created, the returned result still carries the
+// session together with the context error so the caller can release it.
+func (a *AgentBay) CreateWithContext(ctx context.Context, params *CreateSessionParams) (*SessionResult, error) {
 	if params == nil {
 		params = NewCreateSessionParams()
 	}
@@ -151,8 +185,17 @@ func (a *AgentBay) Create(params *CreateSessionParams) (*SessionResult, error) {
 	// Add context sync configurations if provided
 	var persistenceDataList []*mcp.CreateMcpSessionRequestPersistenceDataList
 
-	if len(params.ContextSync) > 0 {
-		for _, contextSync := range params.ContextSync {
+	// Browser context syncs are appended to a copy so params can be reused
+	contextSyncs := append([]*ContextSync{}, params.ContextSync...)
+	if params.BrowserContext != nil {
+		if err := params.BrowserContext.Validate(); err != nil {
+			return nil, err
+		}
+		contextSyncs = append(contextSyncs, params.BrowserContext.GetContextSyncs()...)
+	}
+
+	if len(contextSyncs) > 0 {
+		for _, contextSync := range contextSyncs {
 			persistenceItem := &mcp.CreateMcpSessionRequestPersistenceDataList{
 				ContextId: tea.String(contextSync.ContextID),
 				Path:      tea.String(contextSync.Path),
@@ -177,41 +220,37 @@ func (a *AgentBay) Create(params *CreateSessionParams) (*SessionResult, error) {
 	}
 
 	// Log API request
-	fmt.Println("API Call: CreateMcpSession")
-	fmt.Printf("Request: ")
+	log := a.GetLogger()
+	requestFields := []any{}
 	if createSessionRequest.ContextId != nil {
-		fmt.Printf("ContextId=%s, ", *createSessionRequest.ContextId)
+		requestFields = append(requestFields, "context_id", *createSessionRequest.ContextId)
 	}
 	if createSessionRequest.ImageId != nil {
-		fmt.Printf("ImageId=%s, ", *createSessionRequest.ImageId)
+		requestFields = append(requestFields, "image_id", *createSessionRequest.ImageId)
 	}
 	if createSessionRequest.McpPolicyId != nil {
-		fmt.Printf("PolicyId=%s, ", *createSessionRequest.McpPolicyId)
+		requestFields = append(requestFields, "policy_id", *createSessionRequest.McpPolicyId)
 	}
 	if createSessionRequest.VpcResource != nil {
-		fmt.Printf("VpcResource=%t, ", *createSessionRequest.VpcResource)
+		requestFields = append(requestFields, "vpc_resource", *createSessionRequest.VpcResource)
 	}
 	if createSessionRequest.Labels != nil {
-		fmt.Printf("Labels=%s, ", *createSessionRequest.Labels)
+		requestFields = append(requestFields, "labels", *createSessionRequest.Labels)
 	}
 	if len(createSessionRequest.PersistenceDataList) > 0 {
-		fmt.Printf("PersistenceDataList=%d items, ", len(createSessionRequest.PersistenceDataList))
-		for i, pd := range createSessionRequest.PersistenceDataList {
-			fmt.Printf("Item%d[ContextId=%s, Path=%s", i, tea.StringValue(pd.ContextId), tea.StringValue(pd.Path))
-			if pd.Policy != nil {
-				fmt.Printf(", Policy=%s", tea.StringValue(pd.Policy))
-			}
-			fmt.Printf("], ")
-		}
+		requestFields = append(requestFields, "persistence_data", formatPayload(createSessionRequest.PersistenceDataList))
 	}
-	fmt.Println()
+	logAPIRequest(log, "CreateMcpSession", "", requestFields...)
 
-	response, err := a.Client.CreateMcpSession(createSessionRequest)
+	start := time.Now()
+	response, retries, err := invokeWithRetry(ctx, a.GetRetryPolicy(), log, "CreateMcpSession", func(runtime *dara.RuntimeOptions) (*mcp.CreateMcpSessionResponse, error) {
+		return a.apiClient().CreateMcpSessionWithOptions(createSessionRequest, runtime)
+	})
 
 	// Log API response
 	if err != nil {
-		fmt.Println("Error calling CreateMcpSession:", err)
-		return nil, err
+		logAPIError(log, "CreateMcpSession", "", start, err)
+		return nil, newAPIError("CreateMcpSession", err)
 	}
 
 	// Extract RequestID
@@ -219,17 +258,16 @@ func (a *AgentBay) Create(params *CreateSessionParams) (*SessionResult, error) {
 
 	// Log only the response body
 	if response != nil && response.Body != nil {
-		// Convert response body to JSON for proper printing without escaped characters
-		responseJSON, _ := json.MarshalIndent(response.Body, "", "  ")
-		// Replace \u0026 with & for better readability
-		jsonStr := strings.ReplaceAll(string(responseJSON), "\\u0026", "&")
-		fmt.Println("Response from CreateMcpSession:")
-		fmt.Println(jsonStr)
+		logAPIResponse(log, "CreateMcpSession", "", requestID, start, response.Body)
 	}
 
 	// Check if the session creation was successful
 	if response == nil || response.Body == nil || response.Body.Data == nil {
-		return nil, fmt.Errorf("invalid response from CreateMcpSession")
+		if response != nil && response.Body != nil && response.Body.Code != nil {
+			return nil, newAPIResponseError("CreateMcpSession", tea.StringValue(response.Body.Code),
+				tea.StringValue(response.Body.Message), requestID)
+		}
+		return nil, &APIError{API: "CreateMcpSession", Message: "invalid response", RequestID: requestID}
 	}
 
 	// Check if there's an error message in the response
@@ -238,12 +276,12 @@ func (a *AgentBay) Create(params *CreateSessionParams) (*SessionResult, error) {
 		if response.Body.Data.ErrMsg != nil {
 			errMsg = *response.Body.Data.ErrMsg
 		}
-		return nil, fmt.Errorf("%s", errMsg)
+		return nil, newAPIResponseError("CreateMcpSession", tea.StringValue(response.Body.Code), errMsg, requestID)
 	}
 
 	// Check if SessionId is present
 	if response.Body.Data.SessionId == nil {
-		return nil, fmt.Errorf("no session ID returned from CreateMcpSession")
+		return nil, &APIError{API: "CreateMcpSession", Message: "no session ID returned", RequestID: requestID}
 	}
 
 	// ResourceUrl is optional in CreateMcpSession response
@@ -269,88 +307,113 @@ func (a *AgentBay) Create(params *CreateSessionParams) (*SessionResult, error) {
 		session.ResourceUrl = *response.Body.Data.ResourceUrl
 	}
 
-	a.Sessions.Store(session.SessionID, *session)
-
 	// Apply mobile configuration if provided
 	if params.ExtraConfigs != nil && params.ExtraConfigs.Mobile != nil {
-		fmt.Println("Applying mobile configuration...")
+		log.Info("Applying mobile configuration", logger.KeySessionID, session.SessionID)
 		if err := session.Mobile.Configure(params.ExtraConfigs.Mobile); err != nil {
-			fmt.Printf("Warning: Failed to apply mobile configuration: %v\n", err)
+			log.Warn("Failed to apply mobile configuration", logger.KeySessionID, session.SessionID, logger.KeyError, err)
 			// Continue with session creation even if mobile config fails
 		} else {
-			fmt.Println("Mobile configuration applied successfully")
+			log.Info("Mobile configuration applied successfully", logger.KeySessionID, session.SessionID)
 		}
 	}
 
 	// For VPC sessions, automatically fetch MCP tools information
 	if params.IsVpc {
-		fmt.Println("VPC session detected, automatically fetching MCP tools...")
-		toolsResult, err := session.ListMcpTools()
+		log.Info("VPC session detected, automatically fetching MCP tools", logger.KeySessionID, session.SessionID)
+		toolsResult, err := session.ListMcpToolsWithContext(ctx)
 		if err != nil {
-			fmt.Printf("Warning: Failed to fetch MCP tools for VPC session: %v\n", err)
+			log.Warn("Failed to fetch MCP tools for VPC session", logger.KeySessionID, session.SessionID, logger.KeyError, err)
 			// Continue with session creation even if tools fetch fails
 		} else {
-			fmt.Printf("Successfully fetched %d MCP tools for VPC session (RequestID: %s)\n",
-				len(toolsResult.Tools), toolsResult.RequestID)
+			log.Info("Fetched MCP tools for VPC session", logger.KeySessionID, session.SessionID,
+				logger.KeyRequestID, toolsResult.RequestID, "tool_count", len(toolsResult.Tools))
 		}
 	}
 
+	a.Sessions.Store(session.SessionID, *session)
+
 	// If we have persistence data, wait for context synchronization
 	if needsContextSync {
-		fmt.Println("Waiting for context synchronization to complete...")
+		log.Info("Waiting for context synchronization to complete", logger.KeySessionID, session.SessionID)
+
+		if err := waitForContextSync(ctx, session); err != nil {
+			return &SessionResult{
+				ApiResponse: models.ApiResponse{
+					RequestID:  requestID,
+					RetryCount: retries,
+				},
+				Session:      session,
+				ErrorMessage: fmt.Sprintf("context synchronization wait aborted: %v", err),
+			}, err
+		}
+	}
 
-		// Wait for context synchronization to complete
-		const maxRetries = 150                        // Maximum number of retries
-		const retryInterval = 1500 * time.Millisecond // 1.5 seconds between retries
+	// Return result with RequestID
+	return &SessionResult{
+		ApiResponse: models.ApiResponse{
+			RequestID:  requestID,
+			RetryCount: retries,
+		},
+		Session: session,
+	}, nil
+}
 
-		for retry := 0; retry < maxRetries; retry++ {
-			// Get context status data
-			infoResult, err := session.Context.Info()
-			if err != nil {
-				fmt.Printf("Error getting context info on attempt %d: %v\n", retry+1, err)
-				time.Sleep(retryInterval)
-				continue
+// waitForContextSync polls the context status of a freshly created session until every
+// context item has finished synchronizing, the retry budget is exhausted, or ctx is done.
+func waitForContextSync(ctx context.Context, session *Session) error {
+	const maxRetries = 150                        // Maximum number of retries
+	const retryInterval = 1500 * time.Millisecond // 1.5 seconds between retries
+	log := session.GetLogger()
+
+	for retry := 0; retry < maxRetries; retry++ {
+		// Get context status data
+		infoResult, err := session.Context.InfoWithContext(ctx, "", "", "")
+		if err != nil && infoResult == nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
 			}
+			log.Warn("Failed to get context info", logger.KeySessionID, session.SessionID, "attempt", retry+1, logger.KeyError, err)
+			if err := sleepWithContext(ctx, retryInterval); err != nil {
+				return err
+			}
+			continue
+		}
 
-			// Check if all context items have status "Success" or "Failed"
-			allCompleted := true
-			hasFailure := false
+		// Check if all context items have status "Success" or "Failed"
+		allCompleted := true
+		hasFailure := false
 
-			for _, item := range infoResult.ContextStatusData {
-				fmt.Printf("Context %s status: %s, path: %s\n", item.ContextId, item.Status, item.Path)
+		for _, item := range infoResult.ContextStatusData {
+			log.Debug("Context status", logger.KeySessionID, session.SessionID, "context_id", item.ContextId, "status", item.Status, "path", item.Path)
 
-				if item.Status != "Success" && item.Status != "Failed" {
-					allCompleted = false
-					break
-				}
+			if item.Status != "Success" && item.Status != "Failed" {
+				allCompleted = false
+				break
+			}
 
-				if item.Status == "Failed" {
-					hasFailure = true
-					fmt.Printf("Context synchronization failed for %s: %s\n", item.ContextId, item.ErrorMessage)
-				}
+			if item.Status == "Failed" {
+				hasFailure = true
+				log.Error("Context synchronization failed", logger.KeySessionID, session.SessionID, "context_id", item.ContextId, logger.KeyError, item.ErrorMessage)
 			}
+		}
 
-			if allCompleted || len(infoResult.ContextStatusData) == 0 {
-				if hasFailure {
-					fmt.Println("Context synchronization completed with failures")
-				} else {
-					fmt.Println("Context synchronization completed successfully")
-				}
-				break
+		if allCompleted || len(infoResult.ContextStatusData) == 0 {
+			if hasFailure {
+				log.Warn("Context synchronization completed with failures", logger.KeySessionID, session.SessionID)
+			} else {
+				log.Info("Context synchronization completed successfully", logger.KeySessionID, session.SessionID)
 			}
+			return nil
+		}
 
-			fmt.Printf("Waiting for context synchronization, attempt %d/%d\n", retry+1, maxRetries)
-			time.Sleep(retryInterval)
+		log.Debug("Waiting for context synchronization", logger.KeySessionID, session.SessionID, "attempt", retry+1, "max_attempts", maxRetries)
+		if err := sleepWithContext(ctx, retryInterval); err != nil {
+			return err
 		}
 	}
 
-	// Return result with RequestID
-	return &SessionResult{
-		ApiResponse: models.ApiResponse{
-			RequestID: requestID,
-		},
-		Session: session,
-	}, nil
+	return nil
 }
 
 // ListSessionParams contains parameters for listing sessions
@@ -396,26 +459,27 @@ func (a *AgentBay) ListByLabels(params *ListSessionParams) (*SessionListResult,
 	}
 
 	// Log API request
-	fmt.Println("API Call: ListSession")
-	fmt.Printf("Request: Labels=%s, MaxResults=%d", *listSessionRequest.Labels, *listSessionRequest.MaxResults)
-	if listSessionRequest.NextToken != nil {
-		fmt.Printf(", NextToken=%s", *listSessionRequest.NextToken)
-	}
-	fmt.Println()
+	log := a.GetLogger()
+	logAPIRequest(log, "ListSession", "", "labels", *listSessionRequest.Labels,
+		"max_results", *listSessionRequest.MaxResults, "next_token", tea.StringValue(listSessionRequest.NextToken))
+
+	start := time.Now()
 
-	response, err := a.Client.ListSession(listSessionRequest)
+	response, retries, err := invokeWithRetry(context.Background(), a.GetRetryPolicy(), log, "ListSession", func(runtime *dara.RuntimeOptions) (*mcp.ListSessionResponse, error) {
+		return a.apiClient().ListSessionWithOptions(listSessionRequest, runtime)
+	})
 
 	// Log API response
 	if err != nil {
-		fmt.Println("Error calling ListSession:", err)
-		return nil, err
+		logAPIError(log, "ListSession", "", start, err)
+		return nil, newAPIError("ListSession", err)
 	}
 
 	// Extract RequestID
 	requestID := models.ExtractRequestID(response)
 
 	if response != nil && response.Body != nil {
-		fmt.Println("Response from ListSession:", response.Body)
+		logAPIResponse(log, "ListSession", "", requestID, start, response.Body)
 	}
 
 	var sessions []Session
@@ -455,7 +519,8 @@ func (a *AgentBay) ListByLabels(params *ListSessionParams) (*SessionListResult,
 
 	return &SessionListResult{
 		ApiResponse: models.ApiResponse{
-			RequestID: requestID,
+			RequestID:  requestID,
+			RetryCount: retries,
 		},
 		SessionIds: sessionIds,
 		NextToken:  nextToken,
@@ -465,6 +530,7 @@ func (a *AgentBay) ListByLabels(params *ListSessionParams) (*SessionListResult,
 }
 
 // List returns paginated list of session IDs filtered by labels.
+// Reaching page N requests the N-1 pages before it; use ListAll or ListFrom to walk the pages in order.
 //
 // Parameters:
 //   - labels: Optional labels to filter sessions (can be nil for no filtering)
@@ -498,6 +564,11 @@ func (a *AgentBay) ListByLabels(params *ListSessionParams) (*SessionListResult,
 //	    fmt.Printf("Request ID: %s\n", result.RequestID)
 //	}
 func (a *AgentBay) List(labels map[string]string, page *int, limit *int32) (*SessionListResult, error) {
+	return a.ListWithContext(context.Background(), labels, page, limit)
+}
+
+// ListWithContext is like List but aborts the page traversal and API calls when ctx is done.
+func (a *AgentBay) ListWithContext(ctx context.Context, labels map[string]string, page *int, limit *int32) (*SessionListResult, error) {
 	// Set default values
 	if labels == nil {
 		labels = make(map[string]string)
@@ -528,6 +599,7 @@ func (a *AgentBay) List(labels map[string]string, page *int, limit *int32) (*Ses
 
 	// Calculate next_token based on page number
 	nextToken := ""
+	retryCount := 0
 	if page != nil && *page > 1 {
 		// We need to fetch pages 1 through page-1 to get the next_token
 		currentPage := 1
@@ -542,35 +614,45 @@ func (a *AgentBay) List(labels map[string]string, page *int, limit *int32) (*Ses
 				listSessionRequest.NextToken = tea.String(nextToken)
 			}
 
-			response, err := a.Client.ListSession(listSessionRequest)
+			response, retries, err := invokeWithRetry(ctx, a.GetRetryPolicy(), a.GetLogger(), "ListSession", func(runtime *dara.RuntimeOptions) (*mcp.ListSessionResponse, error) {
+				return a.apiClient().ListSessionWithOptions(listSessionRequest, runtime)
+			})
+			retryCount += retries
 			if err != nil {
 				return &SessionListResult{
 					ApiResponse: models.ApiResponse{
-						RequestID: models.ExtractRequestID(response),
+						RequestID:  models.ExtractRequestID(response),
+						RetryCount: retryCount,
 					},
 					SessionIds: []string{},
 					NextToken:  "",
 					MaxResults: actualLimit,
 					TotalCount: 0,
-				}, fmt.Errorf("cannot reach page %d: %v", *page, err)
+				}, fmt.Errorf("cannot reach page %d: %w", *page, newAPIError("ListSession", err))
 			}
 
 			if response.Body == nil || response.Body.Success == nil || !*response.Body.Success {
 				errorMsg := "Unknown error"
+				code := ""
+				if response.Body != nil {
+					code = tea.StringValue(response.Body.Code)
+				}
 				if response.Body != nil && response.Body.Message != nil {
 					errorMsg = *response.Body.Message
-				} else if response.Body != nil && response.Body.Code != nil {
-					errorMsg = *response.Body.Code
+				} else if code != "" {
+					errorMsg = code
 				}
 				return &SessionListResult{
 					ApiResponse: models.ApiResponse{
-						RequestID: models.ExtractRequestID(response),
+						RequestID:  models.ExtractRequestID(response),
+						RetryCount: retryCount,
 					},
 					SessionIds: []string{},
 					NextToken:  "",
 					MaxResults: actualLimit,
 					TotalCount: 0,
-				}, fmt.Errorf("cannot reach page %d: %s", *page, errorMsg)
+				}, fmt.Errorf("cannot reach page %d: %w", *page, newAPIResponseError("ListSession",
+					code, errorMsg, models.ExtractRequestID(response)))
 			}
 
 			if response.Body.NextToken == nil || *response.Body.NextToken == "" {
@@ -581,7 +663,8 @@ func (a *AgentBay) List(labels map[string]string, page *int, limit *int32) (*Ses
 				}
 				return &SessionListResult{
 					ApiResponse: models.ApiResponse{
-						RequestID: models.ExtractRequestID(response),
+						RequestID:  models.ExtractRequestID(response),
+						RetryCount: retryCount,
 					},
 					SessionIds: []string{},
 					NextToken:  "",
@@ -595,9 +678,18 @@ func (a *AgentBay) List(labels map[string]string, page *int, limit *int32) (*Ses
 	}
 
 	// Make the actual request for the desired page
+	result, err := a.listPage(ctx, string(labelsJSON), actualLimit, nextToken)
+	if result != nil {
+		result.RetryCount += retryCount
+	}
+	return result, err
+}
+
+// listPage requests the page of sessions matching labelsJSON that starts at nextToken
+func (a *AgentBay) listPage(ctx context.Context, labelsJSON string, actualLimit int32, nextToken string) (*SessionListResult, error) {
 	listSessionRequest := &mcp.ListSessionRequest{
 		Authorization: tea.String("Bearer " + a.APIKey),
-		Labels:        tea.String(string(labelsJSON)),
+		Labels:        tea.String(labelsJSON),
 		MaxResults:    tea.Int32(actualLimit),
 	}
 	if nextToken != "" {
@@ -605,45 +697,53 @@ func (a *AgentBay) List(labels map[string]string, page *int, limit *int32) (*Ses
 	}
 
 	// Log API request
-	fmt.Println("API Call: ListSession")
-	fmt.Printf("Request: Labels=%s, MaxResults=%d", *listSessionRequest.Labels, *listSessionRequest.MaxResults)
-	if listSessionRequest.NextToken != nil {
-		fmt.Printf(", NextToken=%s", *listSessionRequest.NextToken)
-	}
-	fmt.Println()
+	log := a.GetLogger()
+	logAPIRequest(log, "ListSession", "", "labels", *listSessionRequest.Labels,
+		"max_results", *listSessionRequest.MaxResults, "next_token", tea.StringValue(listSessionRequest.NextToken))
+
+	start := time.Now()
 
-	response, err := a.Client.ListSession(listSessionRequest)
+	response, retries, err := invokeWithRetry(ctx, a.GetRetryPolicy(), log, "ListSession", func(runtime *dara.RuntimeOptions) (*mcp.ListSessionResponse, error) {
+		return a.apiClient().ListSessionWithOptions(listSessionRequest, runtime)
+	})
+	retryCount := retries
 
 	// Log API response
 	if err != nil {
-		fmt.Println("Error calling ListSession:", err)
-		return nil, err
+		logAPIError(log, "ListSession", "", start, err)
+		return nil, newAPIError("ListSession", err)
 	}
 
 	// Extract RequestID
 	requestID := models.ExtractRequestID(response)
 
 	if response != nil && response.Body != nil {
-		fmt.Println("Response from ListSession:", response.Body)
+		logAPIResponse(log, "ListSession", "", requestID, start, response.Body)
 	}
 
 	// Check for errors in the response
 	if response.Body == nil || response.Body.Success == nil || !*response.Body.Success {
 		errorMsg := "Unknown error"
+		code := ""
+		if response.Body != nil {
+			code = tea.StringValue(response.Body.Code)
+		}
 		if response.Body != nil && response.Body.Message != nil {
 			errorMsg = *response.Body.Message
-		} else if response.Body != nil && response.Body.Code != nil {
-			errorMsg = *response.Body.Code
+		} else if code != "" {
+			errorMsg = code
 		}
 		return &SessionListResult{
 			ApiResponse: models.ApiResponse{
-				RequestID: requestID,
+				RequestID:  requestID,
+				RetryCount: retryCount,
 			},
 			SessionIds: []string{},
 			NextToken:  "",
 			MaxResults: actualLimit,
 			TotalCount: 0,
-		}, fmt.Errorf("failed to list sessions: %s", errorMsg)
+		}, fmt.Errorf("failed to list sessions: %w", newAPIResponseError("ListSession",
+			code, errorMsg, requestID))
 	}
 
 	var sessionIds []string
@@ -675,7 +775,8 @@ func (a *AgentBay) List(labels map[string]string, page *int, limit *int32) (*Ses
 
 	return &SessionListResult{
 		ApiResponse: models.ApiResponse{
-			RequestID: requestID,
+			RequestID:  requestID,
+			RetryCount: retryCount,
 		},
 		SessionIds: sessionIds,
 		NextToken:  nextTokenResult,
@@ -686,7 +787,12 @@ func (a *AgentBay) List(labels map[string]string, page *int, limit *int32) (*Ses
 
 // Delete deletes a session by ID.
 func (a *AgentBay) Delete(session *Session, syncContext ...bool) (*DeleteResult, error) {
-	result, err := session.Delete(syncContext...)
+	return a.DeleteWithContext(context.Background(), session, syncContext...)
+}
+
+// DeleteWithContext deletes a session by ID, honouring the cancellation and deadline of ctx.
+func (a *AgentBay) DeleteWithContext(ctx context.Context, session *Session, syncContext ...bool) (*DeleteResult, error) {
+	result, err := session.DeleteWithContext(ctx, syncContext...)
 	if err == nil {
 		a.Sessions.Delete(session.SessionID)
 	}
@@ -718,33 +824,44 @@ type GetSessionData struct {
 
 // GetSession retrieves session information by session ID
 func (a *AgentBay) GetSession(sessionID string) (*GetSessionResult, error) {
+	return a.GetSessionWithContext(context.Background(), sessionID)
+}
+
+// GetSessionWithContext retrieves session information by session ID, honouring the
+// cancellation and deadline of ctx.
+func (a *AgentBay) GetSessionWithContext(ctx context.Context, sessionID string) (*GetSessionResult, error) {
 	getSessionRequest := &mcp.GetSessionRequest{
 		Authorization: tea.String("Bearer " + a.APIKey),
 		SessionId:     tea.String(sessionID),
 	}
 
 	// Log API request
-	fmt.Println("API Call: GetSession")
-	fmt.Printf("Request: SessionId=%s\n", *getSessionRequest.SessionId)
+	log := a.GetLogger()
+	logAPIRequest(log, "GetSession", sessionID)
+
+	start := time.Now()
 
-	response, err := a.Client.GetSession(getSessionRequest)
+	response, retries, err := invokeWithRetry(ctx, a.GetRetryPolicy(), log, "GetSession", func(runtime *dara.RuntimeOptions) (*mcp.GetSessionResponse, error) {
+		return a.apiClient().GetSessionWithOptions(getSessionRequest, runtime)
+	})
 
 	// Log API response
 	if err != nil {
-		fmt.Println("Error calling GetSession:", err)
-		return nil, err
+		logAPIError(log, "GetSession", sessionID, start, err)
+		return nil, sessionError(sessionID, newAPIError("GetSession", err))
 	}
 
 	// Extract RequestID
 	requestID := models.ExtractRequestID(response)
 
 	if response != nil && response.Body != nil {
-		fmt.Println("Response from GetSession:", response.Body)
+		logAPIResponse(log, "GetSession", sessionID, requestID, start, response.Body)
 	}
 
 	result := &GetSessionResult{
 		ApiResponse: models.ApiResponse{
-			RequestID: requestID,
+			RequestID:  requestID,
+			RetryCount: retries,
 		},
 	}
 
@@ -767,7 +884,7 @@ func (a *AgentBay) GetSession(sessionID string) (*GetSessionResult, error) {
 				message = "Unknown error"
 			}
 			result.ErrorMessage = fmt.Sprintf("[%s] %s", code, message)
-			return result, nil
+			return result, sessionError(sessionID, newAPIResponseError("GetSession", code, message, requestID))
 		}
 
 		if response.Body.Data != nil {
@@ -810,13 +927,17 @@ func (a *AgentBay) GetSession(sessionID string) (*GetSessionResult, error) {
 
 // Get retrieves a session by its ID.
 // This method calls the GetSession API and returns a SessionResult containing the Session object and request ID.
+// The VPC information and the resource URL are restored from the API. The API does not return the image, so
+// ImageId is only restored for sessions created or imported by this client; use Session.Export and Import to
+// resume a session in another process. The MCP tools of VPC sessions are fetched like in Create.
 //
 // Parameters:
 //   - sessionID: The ID of the session to retrieve
 //
 // Returns:
 //   - *SessionResult: Result containing the Session instance, request ID, and success status
-//   - error: An error if the operation fails
+//   - error: An error if the operation fails. If the session does not exist, the error is a
+//     *SessionNotFoundError that matches ErrSessionNotFound through errors.Is.
 //
 // Example:
 //
@@ -829,6 +950,25 @@ func (a *AgentBay) GetSession(sessionID string) (*GetSessionResult, error) {
 //	    fmt.Printf("Request ID: %s\n", result.RequestID)
 //	}
 func (a *AgentBay) Get(sessionID string) (*SessionResult, error) {
+	return a.GetWithContext(context.Background(), sessionID)
+}
+
+// GetWithContext is like Get but honours the cancellation and deadline of ctx.
+func (a *AgentBay) GetWithContext(ctx context.Context, sessionID string) (*SessionResult, error) {
+	// GetSession does not return the image or the MCP tools; restore them from the local cache
+	// when the session was created or imported by this client
+	var known *Session
+	if cached, ok := a.Sessions.Load(sessionID); ok {
+		if previous, ok := cached.(Session); ok {
+			known = &previous
+		}
+	}
+	return a.getWithContext(ctx, sessionID, known)
+}
+
+// getWithContext restores a session from the GetSession API, taking the image and the MCP tools
+// from known if it is not nil, and stores it in the session cache on success
+func (a *AgentBay) getWithContext(ctx context.Context, sessionID string, known *Session) (*SessionResult, error) {
 	if sessionID == "" {
 		return &SessionResult{
 			ApiResponse: models.ApiResponse{
@@ -840,30 +980,32 @@ func (a *AgentBay) Get(sessionID string) (*SessionResult, error) {
 	}
 
 	// Call GetSession API
-	getResult, err := a.GetSession(sessionID)
+	getResult, err := a.GetSessionWithContext(ctx, sessionID)
 	if err != nil {
+		var apiResponse models.ApiResponse
+		if getResult != nil {
+			apiResponse = getResult.ApiResponse
+		}
 		return &SessionResult{
-			ApiResponse: models.ApiResponse{
-				RequestID: "",
-			},
+			ApiResponse:  apiResponse,
 			Success:      false,
 			ErrorMessage: fmt.Sprintf("failed to get session %s: %v", sessionID, err),
-		}, nil
+		}, err
 	}
 
 	// Check if the API call was successful
 	if !getResult.Success {
 		errorMsg := "unknown error"
+		var getErr error = &APIError{API: "GetSession", Message: errorMsg, RequestID: getResult.RequestID}
 		if getResult.Data != nil && !getResult.Data.Success {
 			errorMsg = "Session not found"
+			getErr = &SessionNotFoundError{SessionID: sessionID, RequestID: getResult.RequestID}
 		}
 		return &SessionResult{
-			ApiResponse: models.ApiResponse{
-				RequestID: getResult.RequestID,
-			},
+			ApiResponse:  getResult.ApiResponse,
 			Success:      false,
 			ErrorMessage: fmt.Sprintf("failed to get session %s: %s", sessionID, errorMsg),
-		}, nil
+		}, getErr
 	}
 
 	// Create the Session object
@@ -878,14 +1020,29 @@ func (a *AgentBay) Get(sessionID string) (*SessionResult, error) {
 		session.ResourceUrl = getResult.Data.ResourceUrl
 	}
 
+	if known != nil {
+		session.ImageId = known.ImageId
+		session.McpTools = known.McpTools
+	}
+
+	// For VPC sessions, fetch MCP tools like Create does, since tool calls are routed by them.
+	// The tools depend on the image, so they cannot be fetched if the image is unknown.
+	if session.IsVpcEnabled && len(session.McpTools) == 0 {
+		log := a.GetLogger()
+		if session.ImageId == "" {
+			log.Warn("Image of VPC session is unknown, MCP tools are not available; use Import to resume a session created by another client",
+				logger.KeySessionID, sessionID)
+		} else if _, err := session.ListMcpToolsWithContext(ctx); err != nil {
+			log.Warn("Failed to fetch MCP tools for VPC session", logger.KeySessionID, sessionID, logger.KeyError, err)
+		}
+	}
+
 	// Store the session in the local cache
 	a.Sessions.Store(sessionID, *session)
 
 	return &SessionResult{
-		ApiResponse: models.ApiResponse{
-			RequestID: getResult.RequestID,
-		},
-		Success: true,
-		Session: session,
+		ApiResponse: getResult.ApiResponse,
+		Success:     true,
+		Session:     session,
 	}, nil
 }
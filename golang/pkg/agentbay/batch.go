@@ -0,0 +1,248 @@
+package agentbay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/logger"
+)
+
+// DefaultBatchConcurrency is the number of sessions DeleteBatch deletes at the same time
+const DefaultBatchConcurrency = 10
+
+// RateLimiter paces the API calls of CreateBatch and DeleteBatch. Wait blocks until a call may
+// be made or ctx is done. *rate.Limiter of golang.org/x/time/rate implements it.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// WithRateLimiter returns an Option that sets the rate limiter of the batch operations of the
+// AgentBay client.
+func WithRateLimiter(l RateLimiter) Option {
+	return func(c *AgentBayConfig) {
+		c.rateLimiter = l
+	}
+}
+
+// SetRateLimiter replaces the rate limiter of the batch operations. Passing nil removes the limit.
+func (a *AgentBay) SetRateLimiter(l RateLimiter) {
+	a.rateLimiter = l
+}
+
+// errRateLimitExhausted is returned by the Wait of a RateLimiter without refill once its burst
+// is used up
+var errRateLimitExhausted = errors.New("no calls are left and the rate is not positive")
+
+// NewRateLimiter returns a RateLimiter that allows perSecond calls per second on average and
+// bursts of up to burst calls. If perSecond is not positive, only the first burst calls are
+// allowed and later calls to Wait fail at once.
+func NewRateLimiter(perSecond float64, burst int) RateLimiter {
+	if !(perSecond > 0) {
+		perSecond = 0
+	}
+	burst = max(burst, 1)
+	return &tokenBucket{perSecond: perSecond, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// tokenBucket is a token bucket refilled at perSecond tokens per second
+type tokenBucket struct {
+	mu        sync.Mutex
+	perSecond float64
+	burst     float64
+	tokens    float64
+	last      time.Time
+}
+
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.perSecond)
+	b.last = now
+	if b.perSecond == 0 && b.tokens < 1 {
+		b.mu.Unlock()
+		return errRateLimitExhausted
+	}
+	// Take the token now, going into debt if none is left, and wait until it is refilled
+	b.tokens--
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.perSecond * float64(time.Second))
+	}
+	b.mu.Unlock()
+	if wait == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// BatchCreateResult is the result of CreateBatch. Results and Errors have an entry for every
+// requested session, in order.
+type BatchCreateResult struct {
+	// Results holds the result of every session. An entry is nil if the session was not created
+	// or was deleted again because the batch was aborted.
+	Results []*SessionResult
+	// Errors holds the error of every session, nil for the sessions that were created
+	Errors []error
+}
+
+// Sessions returns the sessions that were created
+func (r *BatchCreateResult) Sessions() []*Session {
+	var sessions []*Session
+	for i, result := range r.Results {
+		if r.Errors[i] == nil && result != nil && result.Session != nil {
+			sessions = append(sessions, result.Session)
+		}
+	}
+	return sessions
+}
+
+// BatchDeleteResult is the result of DeleteBatch. Results and Errors have an entry for every
+// session, in order.
+type BatchDeleteResult struct {
+	// Results holds the result of every deletion, nil if it was not attempted
+	Results []*DeleteResult
+	// Errors holds the error of every deletion, nil for the sessions that were deleted
+	Errors []error
+}
+
+// CreateBatch creates n sessions from params, creating up to concurrency sessions at the same
+// time and pacing the calls with the rate limiter of the client. If concurrency is not positive,
+// all sessions are created at the same time.
+//
+// If ctx is done before every creation was started, the batch is aborted: no more sessions are
+// created, the creations in progress are completed, and all created sessions are deleted before
+// the context error is returned. Otherwise the sessions that could be created are kept and the errors
+// of the others are returned joined. Sessions created although their context synchronization
+// wait failed are deleted.
+func (a *AgentBay) CreateBatch(ctx context.Context, params *CreateSessionParams, n, concurrency int) (*BatchCreateResult, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("invalid number of sessions: %d", n)
+	}
+	batch := &BatchCreateResult{Results: make([]*SessionResult, n), Errors: make([]error, n)}
+	aborted := a.runBatch(ctx, n, concurrency, func(i int) error {
+		// Creation is not cancelled, which would leak a session the server already created;
+		// the session is deleted below instead
+		result, err := a.CreateWithContext(context.WithoutCancel(ctx), params)
+		batch.Results[i] = result
+		return err
+	}, batch.Errors)
+
+	// Collect the sessions to delete: all of them if the batch was aborted, otherwise those
+	// whose creation failed after the session was created
+	var cleanup []*Session
+	var indexes []int
+	for i, result := range batch.Results {
+		if result == nil || result.Session == nil {
+			continue
+		}
+		if aborted != nil || batch.Errors[i] != nil {
+			cleanup = append(cleanup, result.Session)
+			indexes = append(indexes, i)
+		}
+	}
+	if len(cleanup) > 0 {
+		a.GetLogger().Warn("Deleting sessions of failed batch", "count", len(cleanup))
+		deleted, _ := a.DeleteBatchWithContext(context.Background(), cleanup)
+		for j, i := range indexes {
+			if aborted != nil {
+				batch.Errors[i] = errors.Join(batch.Errors[i], aborted)
+			}
+			if deleted.Errors[j] != nil {
+				batch.Errors[i] = errors.Join(batch.Errors[i], deleted.Errors[j])
+			} else {
+				batch.Results[i] = nil
+			}
+		}
+	}
+
+	if aborted != nil {
+		return batch, aborted
+	}
+	return batch, errors.Join(batch.Errors...)
+}
+
+// DeleteBatch deletes sessions, DefaultBatchConcurrency at a time, pacing the calls with the
+// rate limiter of the client. It returns the errors of the sessions that could not be deleted
+// joined.
+func (a *AgentBay) DeleteBatch(sessions []*Session, syncContext ...bool) (*BatchDeleteResult, error) {
+	return a.DeleteBatchWithContext(context.Background(), sessions, syncContext...)
+}
+
+// DeleteBatchWithContext deletes sessions like DeleteBatch, honouring the cancellation and
+// deadline of ctx. Sessions whose deletion was not started when ctx is done report the context
+// error.
+func (a *AgentBay) DeleteBatchWithContext(ctx context.Context, sessions []*Session, syncContext ...bool) (*BatchDeleteResult, error) {
+	batch := &BatchDeleteResult{Results: make([]*DeleteResult, len(sessions)), Errors: make([]error, len(sessions))}
+	a.runBatch(ctx, len(sessions), DefaultBatchConcurrency, func(i int) error {
+		result, err := a.DeleteWithContext(ctx, sessions[i], syncContext...)
+		batch.Results[i] = result
+		return err
+	}, batch.Errors)
+	return batch, errors.Join(batch.Errors...)
+}
+
+// runBatch calls fn for the indexes 0 to n-1, up to concurrency at the same time, waiting for the
+// rate limiter before each call, and stores the errors in errs. Calls that are not started because
+// ctx is done report the context error, which is returned; it is nil if all calls were started.
+func (a *AgentBay) runBatch(ctx context.Context, n, concurrency int, fn func(i int) error, errs []error) error {
+	if concurrency <= 0 || concurrency > n {
+		concurrency = n
+	}
+	var mu sync.Mutex
+	var aborted error
+	skip := func(i int, err error) {
+		errs[i] = err
+		mu.Lock()
+		if aborted == nil {
+			aborted = ctx.Err()
+		}
+		mu.Unlock()
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					skip(i, err)
+					continue
+				}
+				if a.rateLimiter != nil {
+					if err := a.rateLimiter.Wait(ctx); err != nil {
+						skip(i, fmt.Errorf("rate limiter: %w", err))
+						continue
+					}
+				}
+				if errs[i] = fn(i); errs[i] != nil {
+					a.GetLogger().Debug("Batch item failed", "index", i, logger.KeyError, errs[i])
+				}
+			}
+		}()
+	}
+	for i := range n {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return aborted
+}
@@ -0,0 +1,345 @@
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/logger"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+)
+
+// ActOptions describes an action for the browser agent to perform in natural language
+type ActOptions struct {
+	Action             string            // Natural language description of the action, e.g. "click the login button"
+	Variables          map[string]string // Values substituted for %name% placeholders in Action
+	TimeoutMs          *int              // Timeout of the action in milliseconds
+	Iframes            *bool             // Whether to look for elements inside iframes
+	DomSettleTimeoutMs *int              // Time to wait for the DOM to settle before acting
+	UseVision          *bool             // Whether to use screenshots in addition to the DOM
+	PageID             string            // CDP target ID of the page; empty means the focused page
+}
+
+// ActResult represents the result of an action performed by the browser agent
+type ActResult struct {
+	models.ApiResponse
+	Success bool
+	Message string
+}
+
+// ObserveOptions describes what the browser agent should look for on the page
+type ObserveOptions struct {
+	Instruction        string // Natural language description of the elements to find
+	Iframes            *bool  // Whether to look for elements inside iframes
+	DomSettleTimeoutMs *int   // Time to wait for the DOM to settle before observing
+	UseVision          *bool  // Whether to use screenshots in addition to the DOM
+	PageID             string // CDP target ID of the page; empty means the focused page
+}
+
+// ObserveResult describes an element found by Observe and the action suggested for it.
+// It can be passed to Agent.ActObserveResult to perform that action.
+type ObserveResult struct {
+	Selector    string                 `json:"selector"`
+	Description string                 `json:"description"`
+	Method      string                 `json:"method"`
+	Arguments   map[string]interface{} `json:"arguments"`
+}
+
+// ExtractOptions describes the data to extract from the page. The shape of the data is given
+// by the type parameter of Extract.
+type ExtractOptions struct {
+	Instruction        string // Natural language description of the data to extract
+	UseTextExtract     *bool  // Whether to extract from the page text rather than the DOM
+	Selector           string // Optional CSS or XPath selector limiting the extraction
+	Iframe             *bool  // Whether to extract from inside iframes
+	DomSettleTimeoutMs *int   // Time to wait for the DOM to settle before extracting
+	UseVision          *bool  // Whether to use screenshots in addition to the DOM
+	PageID             string // CDP target ID of the page; empty means the focused page
+}
+
+// ClipRect is a region of the page in CSS pixels
+type ClipRect struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// ScreenshotOptions configures Agent.Screenshot
+type ScreenshotOptions struct {
+	FullPage  bool      // Capture the full scrollable page
+	Quality   int       // JPEG quality (0-100)
+	Clip      *ClipRect // Optional region to capture
+	TimeoutMs int       // Optional timeout in milliseconds
+	PageID    string    // CDP target ID of the page; empty means the focused page
+}
+
+// NewScreenshotOptions returns screenshot options capturing the full page at quality 80
+func NewScreenshotOptions() *ScreenshotOptions {
+	return &ScreenshotOptions{FullPage: true, Quality: 80}
+}
+
+// Agent performs natural language browser automation on top of the session's MCP tools.
+// The browser must be initialized before any of its methods are called.
+type Agent struct {
+	browser *Browser
+}
+
+// NewAgent creates a browser agent for the given browser
+func NewAgent(browser *Browser) *Agent {
+	return &Agent{browser: browser}
+}
+
+// Navigate opens url in the focused page and returns the tool's description of the result
+func (a *Agent) Navigate(url string) (string, error) {
+	return a.NavigateWithContext(context.Background(), url)
+}
+
+// NavigateWithContext is like Navigate but honours the cancellation and deadline of ctx.
+func (a *Agent) NavigateWithContext(ctx context.Context, url string) (string, error) {
+	result, err := a.callTool(ctx, "page_use_navigate", map[string]interface{}{"url": url})
+	if err != nil {
+		return "", err
+	}
+	return result.Data, nil
+}
+
+// Screenshot captures the page and returns it as a base64 encoded data URL.
+// Passing nil uses NewScreenshotOptions().
+func (a *Agent) Screenshot(options *ScreenshotOptions) (string, error) {
+	return a.ScreenshotWithContext(context.Background(), options)
+}
+
+// ScreenshotWithContext is like Screenshot but honours the cancellation and deadline of ctx.
+func (a *Agent) ScreenshotWithContext(ctx context.Context, options *ScreenshotOptions) (string, error) {
+	if options == nil {
+		options = NewScreenshotOptions()
+	}
+	args := pageArgs(options.PageID)
+	args["full_page"] = options.FullPage
+	if options.Quality > 0 {
+		args["quality"] = options.Quality
+	}
+	if options.Clip != nil {
+		args["clip"] = options.Clip
+	}
+	if options.TimeoutMs > 0 {
+		args["timeout"] = options.TimeoutMs
+	}
+
+	result, err := a.callTool(ctx, "page_use_screenshot", args)
+	if err != nil {
+		return "", err
+	}
+	return result.Data, nil
+}
+
+// Act performs the action described by options.
+// A failed action is reported through ActResult.Success; an error is only returned when the
+// tool could not be called.
+func (a *Agent) Act(options ActOptions) (*ActResult, error) {
+	return a.ActWithContext(context.Background(), options)
+}
+
+// ActWithContext is like Act but honours the cancellation and deadline of ctx.
+func (a *Agent) ActWithContext(ctx context.Context, options ActOptions) (*ActResult, error) {
+	args := pageArgs(options.PageID)
+	args["action"] = options.Action
+	if options.Variables != nil {
+		args["variables"] = options.Variables
+	}
+	setOptional(args, "timeout_ms", options.TimeoutMs)
+	setOptional(args, "iframes", options.Iframes)
+	setOptional(args, "dom_settle_timeout_ms", options.DomSettleTimeoutMs)
+	setOptional(args, "use_vision", options.UseVision)
+
+	return a.act(ctx, options.Action, args)
+}
+
+// ActObserveResult performs the action suggested by a previous call to Observe
+func (a *Agent) ActObserveResult(observed ObserveResult) (*ActResult, error) {
+	return a.ActObserveResultWithContext(context.Background(), observed)
+}
+
+// ActObserveResultWithContext is like ActObserveResult but honours the cancellation and deadline of ctx.
+func (a *Agent) ActObserveResultWithContext(ctx context.Context, observed ObserveResult) (*ActResult, error) {
+	action, err := json.Marshal(map[string]interface{}{
+		"method":    observed.Method,
+		"arguments": observed.Arguments,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal observed action: %w", err)
+	}
+
+	args := pageArgs("")
+	args["action"] = string(action)
+	return a.act(ctx, observed.Method, args)
+}
+
+func (a *Agent) act(ctx context.Context, task string, args map[string]interface{}) (*ActResult, error) {
+	result, err := a.callMcpTool(ctx, "page_use_act", args)
+	if err != nil {
+		return nil, err
+	}
+
+	actResult := &ActResult{
+		ApiResponse: models.ApiResponse{
+			RequestID:  result.RequestID,
+			RetryCount: result.RetryCount,
+		},
+		Success: result.Success && result.Data != "",
+		Message: result.Data,
+	}
+	if !result.Success {
+		actResult.Message = result.ErrorMessage
+	}
+	a.logger().Info("Browser agent action completed", "task", task, "success", actResult.Success,
+		logger.KeyRequestID, result.RequestID)
+	return actResult, nil
+}
+
+// Observe finds the elements on the page that match options.Instruction
+func (a *Agent) Observe(options ObserveOptions) ([]ObserveResult, error) {
+	return a.ObserveWithContext(context.Background(), options)
+}
+
+// ObserveWithContext is like Observe but honours the cancellation and deadline of ctx.
+func (a *Agent) ObserveWithContext(ctx context.Context, options ObserveOptions) ([]ObserveResult, error) {
+	args := pageArgs(options.PageID)
+	args["instruction"] = options.Instruction
+	setOptional(args, "iframes", options.Iframes)
+	setOptional(args, "dom_settle_timeout_ms", options.DomSettleTimeoutMs)
+	setOptional(args, "use_vision", options.UseVision)
+
+	result, err := a.callTool(ctx, "page_use_observe", args)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []struct {
+		Selector    string          `json:"selector"`
+		Description string          `json:"description"`
+		Method      string          `json:"method"`
+		Arguments   json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal([]byte(result.Data), &items); err != nil {
+		return nil, fmt.Errorf("failed to parse observe result: %w", err)
+	}
+
+	observed := make([]ObserveResult, 0, len(items))
+	for _, item := range items {
+		observed = append(observed, ObserveResult{
+			Selector:    item.Selector,
+			Description: item.Description,
+			Method:      item.Method,
+			Arguments:   parseArguments(item.Arguments),
+		})
+	}
+	return observed, nil
+}
+
+// Extract extracts data described by options from the page and decodes it into a T.
+// The JSON schema sent to the agent is generated from T with JSONSchemaOf.
+func Extract[T any](a *Agent, options ExtractOptions) (*T, error) {
+	return ExtractWithContext[T](context.Background(), a, options)
+}
+
+// ExtractWithContext is like Extract but honours the cancellation and deadline of ctx.
+func ExtractWithContext[T any](ctx context.Context, a *Agent, options ExtractOptions) (*T, error) {
+	schema, err := json.Marshal(JSONSchemaOf[T]())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal extract schema: %w", err)
+	}
+
+	args := pageArgs(options.PageID)
+	args["instruction"] = options.Instruction
+	args["field_schema"] = "schema: " + string(schema)
+	if options.Selector != "" {
+		args["selector"] = options.Selector
+	}
+	setOptional(args, "use_text_extract", options.UseTextExtract)
+	setOptional(args, "use_vision", options.UseVision)
+	setOptional(args, "iframe", options.Iframe)
+	setOptional(args, "dom_settle_timeout_ms", options.DomSettleTimeoutMs)
+
+	result, err := a.callTool(ctx, "page_use_extract", args)
+	if err != nil {
+		return nil, err
+	}
+
+	var value T
+	if err := json.Unmarshal([]byte(result.Data), &value); err != nil {
+		return nil, fmt.Errorf("failed to decode extract result: %w", err)
+	}
+	return &value, nil
+}
+
+// Close terminates the browser session managed by the agent
+func (a *Agent) Close() error {
+	return a.CloseWithContext(context.Background())
+}
+
+// CloseWithContext is like Close but honours the cancellation and deadline of ctx.
+func (a *Agent) CloseWithContext(ctx context.Context) error {
+	_, err := a.callTool(ctx, "page_use_close_session", map[string]interface{}{})
+	return err
+}
+
+// callTool calls an MCP tool and reports a tool failure as a *models.ToolError
+func (a *Agent) callTool(ctx context.Context, toolName string, args map[string]interface{}) (*models.McpToolResult, error) {
+	result, err := a.callMcpTool(ctx, toolName, args)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Success {
+		return nil, models.NewToolError(toolName, result)
+	}
+	return result, nil
+}
+
+// callMcpTool calls an MCP tool after checking that the browser has been initialized
+func (a *Agent) callMcpTool(ctx context.Context, toolName string, args map[string]interface{}) (*models.McpToolResult, error) {
+	if !a.browser.IsInitialized() {
+		return nil, ErrBrowserNotInitialized
+	}
+	return models.CallMcpToolWithContext(ctx, a.browser.Session, toolName, args)
+}
+
+func (a *Agent) logger() logger.Logger {
+	return logger.From(a.browser.Session)
+}
+
+// pageArgs returns the tool arguments selecting the page to operate on
+func pageArgs(pageID string) map[string]interface{} {
+	args := map[string]interface{}{"context_id": 0}
+	if pageID != "" {
+		args["page_id"] = pageID
+	}
+	return args
+}
+
+// setOptional adds an optional argument when it is set
+func setOptional[T any](args map[string]interface{}, key string, value *T) {
+	if value != nil {
+		args[key] = *value
+	}
+}
+
+// parseArguments decodes observed action arguments, which the tool returns either as a JSON
+// object or as a string containing one.
+func parseArguments(raw json.RawMessage) map[string]interface{} {
+	if len(raw) == 0 {
+		return map[string]interface{}{}
+	}
+	var arguments map[string]interface{}
+	if err := json.Unmarshal(raw, &arguments); err == nil {
+		return arguments
+	}
+	var encoded string
+	if err := json.Unmarshal(raw, &encoded); err == nil {
+		if err := json.Unmarshal([]byte(encoded), &arguments); err == nil {
+			return arguments
+		}
+	}
+	return map[string]interface{}{}
+}
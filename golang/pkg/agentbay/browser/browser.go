@@ -0,0 +1,378 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/alibabacloud-go/tea/tea"
+	mcp "github.com/aliyun/wuying-agentbay-sdk/golang/api/client"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/logger"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+)
+
+// BrowserDataPath is the path inside the session where browser data is persisted
+const BrowserDataPath = "/tmp/agentbay_browser"
+
+// DefaultExtensionPath is the path inside the session from which browser extensions are loaded
+const DefaultExtensionPath = "/tmp/extensions/"
+
+// ErrBrowserNotInitialized is returned when an operation requires an initialized browser
+var ErrBrowserNotInitialized = errors.New("browser is not initialized")
+
+// Proxy types and wuying proxy strategies
+const (
+	ProxyTypeCustom = "custom"
+	ProxyTypeWuying = "wuying"
+
+	ProxyStrategyRestricted = "restricted"
+	ProxyStrategyPolling    = "polling"
+)
+
+// BrowserProxy configures the proxy used by the browser.
+// A custom proxy requires Server; a wuying proxy requires Strategy ("restricted" or "polling").
+type BrowserProxy struct {
+	Type     string // "custom" or "wuying"
+	Server   string // Proxy server address, required for custom proxies
+	Username string // Optional username for custom proxies
+	Password string // Optional password for custom proxies
+	Strategy string // "restricted" or "polling", required for wuying proxies
+	PollSize int    // Pool size for the polling strategy, defaults to 10
+}
+
+// NewCustomProxy creates a proxy configuration that routes traffic through server
+func NewCustomProxy(server, username, password string) *BrowserProxy {
+	return &BrowserProxy{Type: ProxyTypeCustom, Server: server, Username: username, Password: password}
+}
+
+// NewWuyingProxy creates a proxy configuration that uses the wuying proxy pool with the given strategy
+func NewWuyingProxy(strategy string, pollSize int) *BrowserProxy {
+	return &BrowserProxy{Type: ProxyTypeWuying, Strategy: strategy, PollSize: pollSize}
+}
+
+// Validate checks that the proxy configuration is complete
+func (p *BrowserProxy) Validate() error {
+	switch p.Type {
+	case ProxyTypeCustom:
+		if p.Server == "" {
+			return fmt.Errorf("server is required for custom proxy type")
+		}
+	case ProxyTypeWuying:
+		if p.Strategy != ProxyStrategyRestricted && p.Strategy != ProxyStrategyPolling {
+			return fmt.Errorf("strategy must be restricted or polling for wuying proxy type")
+		}
+		if p.Strategy == ProxyStrategyPolling && p.PollSize < 0 {
+			return fmt.Errorf("pollsize must not be negative for polling strategy")
+		}
+	default:
+		return fmt.Errorf("proxy type must be custom or wuying")
+	}
+	return nil
+}
+
+// ToMap converts the proxy configuration to the format expected by the InitBrowser API
+func (p *BrowserProxy) ToMap() map[string]interface{} {
+	proxyMap := map[string]interface{}{"type": p.Type}
+	switch p.Type {
+	case ProxyTypeCustom:
+		proxyMap["server"] = p.Server
+		if p.Username != "" {
+			proxyMap["username"] = p.Username
+		}
+		if p.Password != "" {
+			proxyMap["password"] = p.Password
+		}
+	case ProxyTypeWuying:
+		proxyMap["strategy"] = p.Strategy
+		if p.Strategy == ProxyStrategyPolling {
+			pollSize := p.PollSize
+			if pollSize == 0 {
+				pollSize = 10
+			}
+			proxyMap["pollsize"] = pollSize
+		}
+	}
+	return proxyMap
+}
+
+// BrowserViewport sets the size of the browser viewport
+type BrowserViewport struct {
+	Width  int
+	Height int
+}
+
+// BrowserScreen sets the size of the screen reported to web pages
+type BrowserScreen struct {
+	Width  int
+	Height int
+}
+
+// BrowserFingerprint constrains the generated browser fingerprint
+type BrowserFingerprint struct {
+	Devices          []string // "desktop" and/or "mobile"
+	OperatingSystems []string // "windows", "macos", "linux", "android" and/or "ios"
+	Locales          []string // e.g. "en-US"
+}
+
+// Validate checks that the fingerprint only uses supported devices and operating systems
+func (f *BrowserFingerprint) Validate() error {
+	for _, device := range f.Devices {
+		if device != "desktop" && device != "mobile" {
+			return fmt.Errorf("device must be desktop or mobile")
+		}
+	}
+	for _, operatingSystem := range f.OperatingSystems {
+		switch operatingSystem {
+		case "windows", "macos", "linux", "android", "ios":
+		default:
+			return fmt.Errorf("operating system must be windows, macos, linux, android or ios")
+		}
+	}
+	return nil
+}
+
+// BrowserOption holds the options used to initialize the browser
+type BrowserOption struct {
+	UseStealth    bool
+	UserAgent     string
+	Viewport      *BrowserViewport
+	Screen        *BrowserScreen
+	Fingerprint   *BrowserFingerprint
+	SolveCaptchas bool
+	Proxies       []*BrowserProxy // At most one proxy is supported
+	ExtensionPath string
+}
+
+// NewBrowserOption creates browser options with default values
+func NewBrowserOption() *BrowserOption {
+	return &BrowserOption{ExtensionPath: DefaultExtensionPath}
+}
+
+// Validate checks the browser options
+func (o *BrowserOption) Validate() error {
+	if len(o.Proxies) > 1 {
+		return fmt.Errorf("proxies list length must be limited to 1")
+	}
+	for _, proxy := range o.Proxies {
+		if proxy == nil {
+			return fmt.Errorf("proxy must not be nil")
+		}
+		if err := proxy.Validate(); err != nil {
+			return err
+		}
+	}
+	if o.Fingerprint != nil {
+		if err := o.Fingerprint.Validate(); err != nil {
+			return err
+		}
+	}
+	if o.ExtensionPath != "" && strings.TrimSpace(o.ExtensionPath) == "" {
+		return fmt.Errorf("extension path cannot be empty")
+	}
+	return nil
+}
+
+// ToMap converts the browser options to the format expected by the InitBrowser API
+func (o *BrowserOption) ToMap() map[string]interface{} {
+	optionMap := map[string]interface{}{
+		"useStealth":    o.UseStealth,
+		"solveCaptchas": o.SolveCaptchas,
+	}
+	if behaviorSimulate := os.Getenv("AGENTBAY_BROWSER_BEHAVIOR_SIMULATE"); behaviorSimulate != "" {
+		optionMap["behaviorSimulate"] = behaviorSimulate != "0"
+	}
+	if o.UserAgent != "" {
+		optionMap["userAgent"] = o.UserAgent
+	}
+	if o.Viewport != nil {
+		optionMap["viewport"] = map[string]interface{}{"width": o.Viewport.Width, "height": o.Viewport.Height}
+	}
+	if o.Screen != nil {
+		optionMap["screen"] = map[string]interface{}{"width": o.Screen.Width, "height": o.Screen.Height}
+	}
+	if o.Fingerprint != nil {
+		fingerprint := map[string]interface{}{}
+		if o.Fingerprint.Devices != nil {
+			fingerprint["devices"] = o.Fingerprint.Devices
+		}
+		if o.Fingerprint.OperatingSystems != nil {
+			fingerprint["operatingSystems"] = o.Fingerprint.OperatingSystems
+		}
+		if o.Fingerprint.Locales != nil {
+			fingerprint["locales"] = o.Fingerprint.Locales
+		}
+		optionMap["fingerprint"] = fingerprint
+	}
+	if o.Proxies != nil {
+		proxies := make([]interface{}, 0, len(o.Proxies))
+		for _, proxy := range o.Proxies {
+			proxies = append(proxies, proxy.ToMap())
+		}
+		optionMap["proxies"] = proxies
+	}
+	if o.ExtensionPath != "" {
+		optionMap["extensionPath"] = o.ExtensionPath
+	}
+	return optionMap
+}
+
+// Browser provides access to the cloud browser of a session. After Initialize, the browser can be
+// driven over the Chrome DevTools Protocol using the URL returned by GetEndpointURL, or in
+// natural language through Agent.
+type Browser struct {
+	Session interface {
+		GetAPIKey() string
+		GetClient() *mcp.Client
+		GetSessionId() string
+		IsVpc() bool
+		NetworkInterfaceIp() string
+		CallMcpTool(toolName string, args interface{}) (*models.McpToolResult, error)
+	}
+	Agent *Agent
+
+	mu          sync.Mutex
+	initialized bool
+	option      *BrowserOption
+	port        int32
+}
+
+// NewBrowser creates a new Browser instance
+func NewBrowser(session interface {
+	GetAPIKey() string
+	GetClient() *mcp.Client
+	GetSessionId() string
+	IsVpc() bool
+	NetworkInterfaceIp() string
+	CallMcpTool(toolName string, args interface{}) (*models.McpToolResult, error)
+}) *Browser {
+	browser := &Browser{
+		Session: session,
+	}
+	browser.Agent = NewAgent(browser)
+	return browser
+}
+
+// Initialize starts the browser with the given options. Passing nil uses NewBrowserOption().
+// It returns true once the browser is ready; calling it again after a successful
+// initialization is a no-op.
+func (b *Browser) Initialize(option *BrowserOption) (bool, error) {
+	return b.InitializeWithContext(context.Background(), option)
+}
+
+// InitializeWithContext is like Initialize but honours the cancellation and deadline of ctx.
+func (b *Browser) InitializeWithContext(ctx context.Context, option *BrowserOption) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.initialized {
+		return true, nil
+	}
+	if option == nil {
+		option = NewBrowserOption()
+	}
+	if err := option.Validate(); err != nil {
+		return false, fmt.Errorf("invalid browser option: %w", err)
+	}
+
+	request := &mcp.InitBrowserRequest{
+		Authorization:  tea.String("Bearer " + b.Session.GetAPIKey()),
+		SessionId:      tea.String(b.Session.GetSessionId()),
+		PersistentPath: tea.String(BrowserDataPath),
+		BrowserOption:  option.ToMap(),
+	}
+
+	log := logger.From(b.Session)
+	log.Debug("API request", logger.KeyAPI, "InitBrowser", logger.KeySessionID, b.Session.GetSessionId())
+
+	response, _, err := models.InvokeAPI(ctx, b.Session, "InitBrowser", func(runtime *dara.RuntimeOptions) (interface{}, error) {
+		return b.Session.GetClient().InitBrowserWithOptions(request, runtime)
+	})
+	if err != nil {
+		log.Error("Failed to initialize browser", logger.KeySessionID, b.Session.GetSessionId(), logger.KeyError, err)
+		return false, err
+	}
+
+	initResponse, _ := response.(*mcp.InitBrowserResponse)
+	if initResponse == nil || initResponse.Body == nil || initResponse.Body.Data == nil || initResponse.Body.Data.Port == nil {
+		apiErr := &models.APIError{API: "InitBrowser", Message: "browser port missing from response"}
+		if initResponse != nil && initResponse.Body != nil {
+			apiErr.Code = tea.StringValue(initResponse.Body.Code)
+			apiErr.RequestID = tea.StringValue(initResponse.Body.RequestId)
+			if message := tea.StringValue(initResponse.Body.Message); message != "" {
+				apiErr.Message = message
+			}
+		}
+		return false, apiErr
+	}
+
+	b.initialized = true
+	b.option = option
+	b.port = *initResponse.Body.Data.Port
+	log.Info("Browser instance initialized", logger.KeySessionID, b.Session.GetSessionId(),
+		logger.KeyRequestID, tea.StringValue(initResponse.Body.RequestId), "port", b.port)
+	return true, nil
+}
+
+// GetEndpointURL returns the Chrome DevTools Protocol URL of the initialized browser.
+// The URL is fetched again on every call, since the link may change over the session lifetime.
+func (b *Browser) GetEndpointURL() (string, error) {
+	return b.GetEndpointURLWithContext(context.Background())
+}
+
+// GetEndpointURLWithContext is like GetEndpointURL but honours the cancellation and deadline of ctx.
+func (b *Browser) GetEndpointURLWithContext(ctx context.Context) (string, error) {
+	b.mu.Lock()
+	initialized, port := b.initialized, b.port
+	b.mu.Unlock()
+
+	if !initialized {
+		return "", ErrBrowserNotInitialized
+	}
+
+	if b.Session.IsVpc() {
+		return fmt.Sprintf("ws://%s:%d", b.Session.NetworkInterfaceIp(), port), nil
+	}
+
+	request := &mcp.GetLinkRequest{
+		Authorization: tea.String("Bearer " + b.Session.GetAPIKey()),
+		SessionId:     tea.String(b.Session.GetSessionId()),
+	}
+	response, _, err := models.InvokeAPI(ctx, b.Session, "GetLink", func(runtime *dara.RuntimeOptions) (interface{}, error) {
+		return b.Session.GetClient().GetLinkWithOptions(request, runtime)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get endpoint URL: %w", err)
+	}
+
+	linkResponse, _ := response.(*mcp.GetLinkResponse)
+	if linkResponse == nil || linkResponse.Body == nil || linkResponse.Body.Data == nil || linkResponse.Body.Data.Url == nil {
+		apiErr := &models.APIError{API: "GetLink", Message: "endpoint URL missing from response"}
+		if linkResponse != nil && linkResponse.Body != nil {
+			apiErr.Code = tea.StringValue(linkResponse.Body.Code)
+			apiErr.RequestID = tea.StringValue(linkResponse.Body.RequestId)
+			if message := tea.StringValue(linkResponse.Body.Message); message != "" {
+				apiErr.Message = message
+			}
+		}
+		return "", apiErr
+	}
+	return *linkResponse.Body.Data.Url, nil
+}
+
+// GetOption returns the options the browser was initialized with, or nil if it is not initialized
+func (b *Browser) GetOption() *BrowserOption {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.option
+}
+
+// IsInitialized reports whether the browser has been initialized
+func (b *Browser) IsInitialized() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.initialized
+}
@@ -0,0 +1,66 @@
+package agentbay
+
+import (
+	"context"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+)
+
+// runtimeOptionsFromContext builds per-request runtime options for the OpenAPI client.
+// When ctx carries a deadline, the read and connect timeouts are capped to the time remaining.
+// The HTTP client of NewHTTPClient applies the timeout of the client configuration instead, so a
+// request abandoned by invokeWithContext ends within that timeout.
+func runtimeOptionsFromContext(ctx context.Context) *dara.RuntimeOptions {
+	runtime := &dara.RuntimeOptions{}
+	if deadline, ok := ctx.Deadline(); ok {
+		remainingMs := int(time.Until(deadline).Milliseconds())
+		if remainingMs < 1 {
+			remainingMs = 1
+		}
+		runtime.ReadTimeout = dara.Int(remainingMs)
+		runtime.ConnectTimeout = dara.Int(remainingMs)
+	}
+	return runtime
+}
+
+// invokeWithContext runs an OpenAPI call and returns as soon as either the call completes or
+// ctx is done. The generated client has no native context support, so the call is executed on
+// its own goroutine with runtime options derived from ctx.
+func invokeWithContext[T any](ctx context.Context, call func(runtime *dara.RuntimeOptions) (T, error)) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	type callResult struct {
+		value T
+		err   error
+	}
+
+	done := make(chan callResult, 1)
+	go func() {
+		value, err := call(runtimeOptionsFromContext(ctx))
+		done <- callResult{value: value, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case res := <-done:
+		return res.value, res.err
+	}
+}
+
+// sleepWithContext pauses for d, returning early with ctx.Err() if ctx is done first.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
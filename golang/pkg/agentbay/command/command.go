@@ -1,18 +1,52 @@
 package command
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	mcp "github.com/aliyun/wuying-agentbay-sdk/golang/api/client"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
 )
 
+// DefaultRunTimeout is the timeout applied by Run when CommandOptions.Timeout is not set
+const DefaultRunTimeout = 60 * time.Second
+
 // CommandResult represents the result of a command execution
 type CommandResult struct {
 	// Embed the basic API response structure
 	models.ApiResponse
 	// Output contains the command execution output
 	Output string
+	// ExitCode is the exit status of the command. It is only set by Run and Stream.
+	ExitCode int
+	// Stdout contains the standard output of the command. It is only set by Run and Stream.
+	Stdout string
+	// Stderr contains the standard error of the command. It is only set by Run and Stream.
+	Stderr string
+	// Duration is the time taken to execute the command, as observed by the client
+	Duration time.Duration
+}
+
+// CommandOptions configures Run and Stream
+type CommandOptions struct {
+	// Command is the shell command line to execute
+	Command string
+	// Timeout bounds the execution time. Run defaults to DefaultRunTimeout; Stream has no
+	// timeout other than its context when Timeout is zero.
+	Timeout time.Duration
+	// Cwd is the working directory of the command, if set
+	Cwd string
+	// Env sets additional environment variables for the command
+	Env map[string]string
+	// PollInterval is the interval at which Stream fetches new output. Defaults to 500ms.
+	PollInterval time.Duration
 }
 
 // Command handles command execution operations in the AgentBay cloud environment.
@@ -61,11 +95,15 @@ func (c *Command) ExecuteCommand(command string, timeoutMs ...int) (*CommandResu
 	// Use Session's CallMcpTool method
 	result, err := c.Session.CallMcpTool("shell", args)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute command: %w", err)
+		return nil, &models.CommandError{Command: command, Err: err}
 	}
 
 	if !result.Success {
-		return nil, fmt.Errorf("command execution failed: %s", result.ErrorMessage)
+		var cause error = models.NewToolError("shell", result)
+		if isTimeoutMessage(result.ErrorMessage) {
+			cause = &models.TimeoutError{Op: "command", Duration: time.Duration(timeout) * time.Millisecond, Err: cause}
+		}
+		return nil, &models.CommandError{Command: command, Err: cause}
 	}
 
 	return &CommandResult{
@@ -75,3 +113,122 @@ func (c *Command) ExecuteCommand(command string, timeoutMs ...int) (*CommandResu
 		Output: result.Data,
 	}, nil
 }
+
+// Run executes a command in the session environment and returns its exit code together with
+// separate standard output and standard error. A non-zero exit code is reported through
+// CommandResult.ExitCode rather than as an error; an error is returned when the command could
+// not be executed or did not complete within its timeout.
+func (c *Command) Run(ctx context.Context, options CommandOptions) (*CommandResult, error) {
+	preamble, err := options.preamble()
+	if err != nil {
+		return nil, &models.CommandError{Command: options.Command, Err: err}
+	}
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = DefaultRunTimeout
+	}
+
+	marker, err := newMarker()
+	if err != nil {
+		return nil, &models.CommandError{Command: options.Command, Err: err}
+	}
+	stderrPath := "/tmp/agentbay_cmd_" + marker + ".err"
+
+	// The command runs in a subshell whose stderr is captured to a file; the wrapper then prints
+	// a marker line carrying the exit status followed by the captured stderr.
+	script := "(\n" + preamble + options.Command + "\n) 2>" + stderrPath + "\n" +
+		"__agentbay_code=$?\n" +
+		"printf '\\n%s%d\\n' '" + markerPrefix(marker) + "' \"$__agentbay_code\"\n" +
+		"cat " + stderrPath + " 2>/dev/null\n" +
+		"rm -f " + stderrPath
+
+	start := time.Now()
+	result, err := models.CallMcpToolWithContext(ctx, c.Session, "shell", map[string]interface{}{
+		"command":    script,
+		"timeout_ms": int(timeout / time.Millisecond),
+	})
+	if err != nil {
+		return nil, &models.CommandError{Command: options.Command, Err: err}
+	}
+	if !result.Success {
+		var cause error = models.NewToolError("shell", result)
+		if isTimeoutMessage(result.ErrorMessage) {
+			cause = &models.TimeoutError{Op: "command", Duration: timeout, Err: cause}
+		}
+		return nil, &models.CommandError{Command: options.Command, Err: cause}
+	}
+
+	stdout, rest, found := strings.Cut(result.Data, "\n"+markerPrefix(marker))
+	if !found {
+		return nil, &models.CommandError{Command: options.Command, Err: fmt.Errorf("exit status missing from command output")}
+	}
+	codeText, stderr, _ := strings.Cut(rest, "\n")
+	exitCode, err := strconv.Atoi(strings.TrimSpace(codeText))
+	if err != nil {
+		return nil, &models.CommandError{Command: options.Command, Err: fmt.Errorf("invalid exit status %q", codeText)}
+	}
+
+	return &CommandResult{
+		ApiResponse: models.ApiResponse{
+			RequestID:  result.RequestID,
+			RetryCount: result.RetryCount,
+		},
+		Output:   stdout,
+		ExitCode: exitCode,
+		Stdout:   stdout,
+		Stderr:   stderr,
+		Duration: time.Since(start),
+	}, nil
+}
+
+// envNamePattern matches valid environment variable names
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// preamble returns the shell lines that change to the working directory and export the
+// environment of the command
+func (o CommandOptions) preamble() (string, error) {
+	if strings.TrimSpace(o.Command) == "" {
+		return "", fmt.Errorf("command cannot be empty")
+	}
+
+	var b strings.Builder
+	if o.Cwd != "" {
+		b.WriteString("cd " + shellQuote(o.Cwd) + " || exit 1\n")
+	}
+	names := make([]string, 0, len(o.Env))
+	for name := range o.Env {
+		if !envNamePattern.MatchString(name) {
+			return "", fmt.Errorf("invalid environment variable name %q", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.WriteString("export " + name + "=" + shellQuote(o.Env[name]) + "\n")
+	}
+	return b.String(), nil
+}
+
+// shellQuote quotes s for use as a single word in a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// newMarker returns a random token used to delimit sections of wrapped command output
+func newMarker() (string, error) {
+	token := make([]byte, 8)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("failed to generate output marker: %w", err)
+	}
+	return hex.EncodeToString(token), nil
+}
+
+func markerPrefix(marker string) string {
+	return "__AGENTBAY_" + marker + "__"
+}
+
+// isTimeoutMessage reports whether a shell tool error message describes a timeout
+func isTimeoutMessage(msg string) bool {
+	msg = strings.ToLower(msg)
+	return strings.Contains(msg, "timed out") || strings.Contains(msg, "timeout")
+}
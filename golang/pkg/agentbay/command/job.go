@@ -0,0 +1,97 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+)
+
+// jobShellTimeoutMs bounds each shell call made to manage a background job
+const jobShellTimeoutMs = 10000
+
+// backgroundJob is a command running detached from the shell tool. Its script, output and exit
+// status are kept in dir inside the session.
+type backgroundJob struct {
+	command *Command
+	options CommandOptions
+	dir     string
+	marker  string
+	pid     int
+}
+
+// newBackgroundJob prepares a job whose files are kept in a new directory named after prefix
+func newBackgroundJob(c *Command, options CommandOptions, prefix string) (*backgroundJob, error) {
+	marker, err := newMarker()
+	if err != nil {
+		return nil, err
+	}
+	return &backgroundJob{
+		command: c,
+		options: options,
+		dir:     "/tmp/" + prefix + marker,
+		marker:  markerPrefix(marker),
+	}, nil
+}
+
+// start writes the command to a script and runs it detached from the shell tool, in its own
+// session when setsid is available so that it can be signalled as a group. redirect is applied to
+// the script and decides where its output goes.
+func (j *backgroundJob) start(ctx context.Context, redirect string) error {
+	preamble, err := j.options.preamble()
+	if err != nil {
+		return err
+	}
+
+	eof := "AGENTBAY_EOF" + j.marker
+	// The exit status is renamed into place, so that readers never see an empty exit file
+	run := "sh " + j.dir + "/run.sh " + redirect + " </dev/null; echo $? >" + j.dir + "/exit.tmp && mv " + j.dir + "/exit.tmp " + j.dir + "/exit"
+	script := "mkdir -p " + j.dir + " && cat > " + j.dir + "/run.sh <<'" + eof + "'\n" +
+		"if command -v bash >/dev/null 2>&1 && [ -z \"$BASH_VERSION\" ]; then exec bash \"$0\"; fi\n" +
+		preamble + j.options.Command + "\n" +
+		eof + "\n" +
+		"if command -v setsid >/dev/null 2>&1; then setsid sh -c '" + run + "' >/dev/null 2>&1 &\n" +
+		"else sh -c '" + run + "' >/dev/null 2>&1 &\n" +
+		"fi\n" +
+		"echo $!"
+
+	result, err := j.shell(ctx, script)
+	if err != nil {
+		return err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(result.Data))
+	if err != nil {
+		return fmt.Errorf("failed to start background command: unexpected output %q", result.Data)
+	}
+	j.pid = pid
+	return nil
+}
+
+// signal sends sig to the job's process group, or to the job itself when it has no group of its own
+func (j *backgroundJob) signal(ctx context.Context, sig Signal) error {
+	pid := strconv.Itoa(j.pid)
+	_, err := j.shell(ctx, "kill -"+string(sig)+" -- -"+pid+" 2>/dev/null || kill -"+string(sig)+" "+pid+" 2>/dev/null; true")
+	return err
+}
+
+// remove deletes the files of the job
+func (j *backgroundJob) remove(ctx context.Context) error {
+	_, err := j.shell(ctx, "rm -rf "+j.dir)
+	return err
+}
+
+func (j *backgroundJob) shell(ctx context.Context, script string) (*models.McpToolResult, error) {
+	result, err := models.CallMcpToolWithContext(ctx, j.command.Session, "shell", map[string]interface{}{
+		"command":    script,
+		"timeout_ms": jobShellTimeoutMs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !result.Success {
+		return nil, models.NewToolError("shell", result)
+	}
+	return result, nil
+}
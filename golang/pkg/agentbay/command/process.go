@@ -0,0 +1,177 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+)
+
+// Signal is the name of a signal that can be sent to a Process
+type Signal string
+
+// Signals supported by Process.Kill
+const (
+	SignalTerm Signal = "TERM"
+	SignalKill Signal = "KILL"
+	SignalInt  Signal = "INT"
+	SignalHup  Signal = "HUP"
+	SignalQuit Signal = "QUIT"
+)
+
+// ErrProcessRunning is returned by Process.ExitCode while the process is still running
+var ErrProcessRunning = errors.New("process is still running")
+
+// ProcessStatus describes the state of a Process
+type ProcessStatus struct {
+	// Running reports whether the process is still running
+	Running bool
+	// ExitCode is the exit status of a finished process, or -1 if it was terminated by a signal
+	// before it could report one
+	ExitCode int
+}
+
+// Process is a command started in the background of the session with Command.Start.
+// Its standard output and standard error are written to a single log file inside the session,
+// which is kept after the process exits.
+type Process struct {
+	// PID is the process ID of the process group leader inside the session
+	PID int
+	// Command is the command line the process was started with
+	Command string
+	// LogPath is the path of the log file inside the session
+	LogPath string
+
+	job *backgroundJob
+}
+
+// Start starts a command in the background of the session and returns a handle to it.
+// Unlike Stream, the process keeps running after ctx is done; use Kill to stop it.
+// CommandOptions.Timeout is ignored.
+func (c *Command) Start(ctx context.Context, options CommandOptions) (*Process, error) {
+	job, err := newBackgroundJob(c, options, "agentbay_proc_")
+	if err != nil {
+		return nil, &models.CommandError{Command: options.Command, Err: err}
+	}
+	logPath := job.dir + "/output.log"
+	if err := job.start(ctx, ">"+logPath+" 2>&1"); err != nil {
+		return nil, &models.CommandError{Command: options.Command, Err: err}
+	}
+
+	return &Process{
+		PID:     job.pid,
+		Command: options.Command,
+		LogPath: logPath,
+		job:     job,
+	}, nil
+}
+
+// Status reports whether the process is running and, once it has finished, its exit code
+func (p *Process) Status(ctx context.Context) (*ProcessStatus, error) {
+	// The exit status is read again after the liveness check so that a process exiting in between
+	// is not mistaken for one that was killed. Zombies count as finished, since sandboxes do not
+	// always reap orphaned processes.
+	pid := strconv.Itoa(p.PID)
+	exitPath := p.job.dir + "/exit"
+	result, err := p.job.shell(ctx, "if [ -f "+exitPath+" ]; then cat "+exitPath+"; "+
+		"elif kill -0 "+pid+" 2>/dev/null && ! grep -qs '^State:.*Z' /proc/"+pid+"/status; then echo running; "+
+		"else cat "+exitPath+" 2>/dev/null || echo killed; fi")
+	if err != nil {
+		return nil, &models.CommandError{Command: p.Command, Err: err}
+	}
+
+	switch state := strings.TrimSpace(result.Data); state {
+	case "running":
+		return &ProcessStatus{Running: true}, nil
+	case "killed":
+		return &ProcessStatus{ExitCode: -1}, nil
+	default:
+		exitCode, err := strconv.Atoi(state)
+		if err != nil {
+			return nil, &models.CommandError{Command: p.Command, Err: fmt.Errorf("invalid process status %q", state)}
+		}
+		return &ProcessStatus{ExitCode: exitCode}, nil
+	}
+}
+
+// ExitCode returns the exit code of the finished process, or ErrProcessRunning if it is still
+// running
+func (p *Process) ExitCode(ctx context.Context) (int, error) {
+	status, err := p.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if status.Running {
+		return 0, ErrProcessRunning
+	}
+	return status.ExitCode, nil
+}
+
+// Wait polls the process every PollInterval until it has finished or ctx is done, and returns
+// its final status
+func (p *Process) Wait(ctx context.Context) (*ProcessStatus, error) {
+	interval := p.job.options.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status, err := p.Status(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !status.Running {
+			return status, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, &models.CommandError{Command: p.Command, Err: ctx.Err()}
+		case <-ticker.C:
+		}
+	}
+}
+
+// Kill sends sig to the process and all processes it started
+func (p *Process) Kill(ctx context.Context, sig Signal) error {
+	switch sig {
+	case SignalTerm, SignalKill, SignalInt, SignalHup, SignalQuit:
+	default:
+		return fmt.Errorf("unsupported signal %q", sig)
+	}
+	if err := p.job.signal(ctx, sig); err != nil {
+		return &models.CommandError{Command: p.Command, Err: err}
+	}
+	return nil
+}
+
+// ReadLogs returns the output the process has written to its log from byte offset on, together
+// with the offset at which the next read should start
+func (p *Process) ReadLogs(ctx context.Context, offset int64) (string, int64, error) {
+	if offset < 0 {
+		return "", offset, fmt.Errorf("offset must not be negative")
+	}
+	result, err := p.job.shell(ctx, "tail -c +"+strconv.FormatInt(offset+1, 10)+" "+p.LogPath+" 2>/dev/null | base64")
+	if err != nil {
+		return "", offset, &models.CommandError{Command: p.Command, Err: err}
+	}
+	data, err := decodeBase64Output(result.Data)
+	if err != nil {
+		return "", offset, &models.CommandError{Command: p.Command, Err: fmt.Errorf("failed to decode process log: %w", err)}
+	}
+	return string(data), offset + int64(len(data)), nil
+}
+
+// Remove deletes the log file and exit status of the process from the session.
+// It should only be called once the process has finished.
+func (p *Process) Remove(ctx context.Context) error {
+	if err := p.job.remove(ctx); err != nil {
+		return &models.CommandError{Command: p.Command, Err: err}
+	}
+	return nil
+}
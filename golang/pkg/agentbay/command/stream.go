@@ -0,0 +1,188 @@
+package command
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+)
+
+// Output stream names used in OutputChunk
+const (
+	StreamStdout = "stdout"
+	StreamStderr = "stderr"
+)
+
+// defaultPollInterval is the interval at which background commands are polled by default
+const defaultPollInterval = 500 * time.Millisecond
+
+// OutputChunk is a piece of command output delivered by Stream
+type OutputChunk struct {
+	// Stream is StreamStdout or StreamStderr
+	Stream string
+	// Data is the output received since the previous chunk of the same stream
+	Data string
+}
+
+// CommandStream is a command running in the background of the session.
+// Output is delivered on Chunks, which is closed when the command has finished.
+type CommandStream struct {
+	// Chunks delivers the output of the command as it is produced
+	Chunks <-chan OutputChunk
+
+	done   chan struct{}
+	result *CommandResult
+	err    error
+}
+
+// Wait blocks until the command has finished and returns its result, which includes the complete
+// output. Chunks that have not been received yet are discarded, so Wait should be called after
+// ranging over Chunks when the output is consumed incrementally.
+func (s *CommandStream) Wait() (*CommandResult, error) {
+	for range s.Chunks {
+	}
+	<-s.done
+	return s.result, s.err
+}
+
+// Stream starts a command in the background of the session and delivers its output
+// incrementally. The output is fetched by polling the shell tool every PollInterval, so Stream
+// works on every image. Cancelling ctx or exceeding Timeout kills the command.
+func (c *Command) Stream(ctx context.Context, options CommandOptions) (*CommandStream, error) {
+	job, err := newBackgroundJob(c, options, "agentbay_cmd_")
+	if err != nil {
+		return nil, &models.CommandError{Command: options.Command, Err: err}
+	}
+
+	start := time.Now()
+	if err := job.start(ctx, ">"+job.dir+"/stdout 2>"+job.dir+"/stderr"); err != nil {
+		return nil, &models.CommandError{Command: options.Command, Err: err}
+	}
+
+	chunks := make(chan OutputChunk, 16)
+	stream := &CommandStream{Chunks: chunks, done: make(chan struct{})}
+	reader := &streamReader{job: job}
+	go func() {
+		defer close(stream.done)
+		defer close(chunks)
+		stream.result, stream.err = reader.follow(ctx, start, chunks)
+	}()
+	return stream, nil
+}
+
+// streamReader collects the output of a streamed background job
+type streamReader struct {
+	job    *backgroundJob
+	stdout strings.Builder
+	stderr strings.Builder
+}
+
+// follow polls the job until it exits, sending new output to chunks
+func (r *streamReader) follow(ctx context.Context, start time.Time, chunks chan<- OutputChunk) (*CommandResult, error) {
+	options := r.job.options
+	interval := options.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	var deadline <-chan time.Time
+	if options.Timeout > 0 {
+		timer := time.NewTimer(options.Timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		exitCode, finished, requestID, err := r.poll(ctx, chunks)
+		if err != nil {
+			r.kill()
+			return nil, &models.CommandError{Command: options.Command, Err: err}
+		}
+		if finished {
+			_ = r.job.remove(context.Background())
+			return &CommandResult{
+				ApiResponse: models.ApiResponse{RequestID: requestID},
+				Output:      r.stdout.String(),
+				ExitCode:    exitCode,
+				Stdout:      r.stdout.String(),
+				Stderr:      r.stderr.String(),
+				Duration:    time.Since(start),
+			}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			r.kill()
+			return nil, &models.CommandError{Command: options.Command, Err: ctx.Err()}
+		case <-deadline:
+			r.kill()
+			return nil, &models.CommandError{Command: options.Command,
+				Err: &models.TimeoutError{Op: "command", Duration: options.Timeout}}
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll fetches the output produced since the previous poll. The exit status is read first, so
+// once it is present the output read in the same call is complete.
+func (r *streamReader) poll(ctx context.Context, chunks chan<- OutputChunk) (int, bool, string, error) {
+	dir, marker := r.job.dir, r.job.marker
+	script := "cat " + dir + "/exit 2>/dev/null; printf '" + marker + "'; " +
+		"tail -c +" + strconv.Itoa(r.stdout.Len()+1) + " " + dir + "/stdout 2>/dev/null | base64; printf '" + marker + "'; " +
+		"tail -c +" + strconv.Itoa(r.stderr.Len()+1) + " " + dir + "/stderr 2>/dev/null | base64"
+
+	result, err := r.job.shell(ctx, script)
+	if err != nil {
+		return 0, false, "", err
+	}
+	parts := strings.Split(result.Data, marker)
+	if len(parts) != 3 {
+		return 0, false, result.RequestID, fmt.Errorf("unexpected output while polling command")
+	}
+
+	for i, output := range []struct {
+		name    string
+		builder *strings.Builder
+	}{{StreamStdout, &r.stdout}, {StreamStderr, &r.stderr}} {
+		data, err := decodeBase64Output(parts[i+1])
+		if err != nil {
+			return 0, false, result.RequestID, fmt.Errorf("failed to decode command %s: %w", output.name, err)
+		}
+		if len(data) == 0 {
+			continue
+		}
+		output.builder.Write(data)
+		select {
+		case chunks <- OutputChunk{Stream: output.name, Data: string(data)}:
+		case <-ctx.Done():
+			return 0, false, result.RequestID, ctx.Err()
+		}
+	}
+
+	exitText := strings.TrimSpace(parts[0])
+	if exitText == "" {
+		return 0, false, result.RequestID, nil
+	}
+	exitCode, err := strconv.Atoi(exitText)
+	if err != nil {
+		return 0, false, result.RequestID, fmt.Errorf("invalid exit status %q", exitText)
+	}
+	return exitCode, true, result.RequestID, nil
+}
+
+// kill terminates the job and removes its files. It runs detached from the caller's context,
+// which is usually already done.
+func (r *streamReader) kill() {
+	_ = r.job.signal(context.Background(), SignalTerm)
+	_ = r.job.remove(context.Background())
+}
+
+// decodeBase64Output decodes base64 text as printed by the base64 utility, which wraps lines
+func decodeBase64Output(text string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(text), ""))
+}
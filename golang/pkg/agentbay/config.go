@@ -6,13 +6,15 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/logger"
 	"github.com/joho/godotenv"
 )
 
 // Config stores SDK configuration
 type Config struct {
-	Endpoint  string `json:"endpoint"`
-	TimeoutMs int    `json:"timeout_ms"`
+	Endpoint  string       `json:"endpoint"`
+	TimeoutMs int          `json:"timeout_ms"`
+	Retry     *RetryPolicy `json:"retry,omitempty"` // Retry policy for API calls; nil means DefaultRetryPolicy()
 }
 
 // DefaultConfig returns the default configuration
@@ -40,7 +42,7 @@ func FindDotEnvFile(startPath string) string {
 	if startPath == "" {
 		workingDir, err := os.Getwd()
 		if err != nil {
-			fmt.Printf("Warning: Failed to get current working directory: %v\n", err)
+			logger.Default().Warn("Failed to get current working directory", logger.KeyError, err)
 			return ""
 		}
 		startPath = workingDir
@@ -48,7 +50,7 @@ func FindDotEnvFile(startPath string) string {
 
 	currentPath, err := filepath.Abs(startPath)
 	if err != nil {
-		fmt.Printf("Warning: Failed to resolve absolute path: %v\n", err)
+		logger.Default().Warn("Failed to resolve absolute path", "path", startPath, logger.KeyError, err)
 		return ""
 	}
 
@@ -56,14 +58,14 @@ func FindDotEnvFile(startPath string) string {
 	for {
 		envFile := filepath.Join(currentPath, ".env")
 		if _, err := os.Stat(envFile); err == nil {
-			fmt.Printf("Found .env file at: %s\n", envFile)
+			logger.Default().Debug("Found .env file", "path", envFile)
 			return envFile
 		}
 
 		// Check if this is a git repository root
 		gitDir := filepath.Join(currentPath, ".git")
 		if _, err := os.Stat(gitDir); err == nil {
-			fmt.Printf("Found git repository root at: %s\n", currentPath)
+			logger.Default().Debug("Found git repository root", "path", currentPath)
 		}
 
 		parentPath := filepath.Dir(currentPath)
@@ -88,13 +90,13 @@ func LoadDotEnvWithFallback(customEnvPath string) {
 		if _, err := os.Stat(customEnvPath); err == nil {
 			err = godotenv.Load(customEnvPath)
 			if err != nil {
-				fmt.Printf("Warning: Failed to load custom .env file %s: %v\n", customEnvPath, err)
+				logger.Default().Warn("Failed to load custom .env file", "path", customEnvPath, logger.KeyError, err)
 			} else {
-				fmt.Printf("Loaded custom .env file from: %s\n", customEnvPath)
+				logger.Default().Debug("Loaded custom .env file", "path", customEnvPath)
 				return
 			}
 		} else {
-			fmt.Printf("Warning: Custom .env file not found: %s\n", customEnvPath)
+			logger.Default().Warn("Custom .env file not found", "path", customEnvPath)
 		}
 	}
 
@@ -103,12 +105,12 @@ func LoadDotEnvWithFallback(customEnvPath string) {
 	if envFile != "" {
 		err := godotenv.Load(envFile)
 		if err != nil {
-			fmt.Printf("Warning: Failed to load .env file %s: %v\n", envFile, err)
+			logger.Default().Warn("Failed to load .env file", "path", envFile, logger.KeyError, err)
 		} else {
-			fmt.Printf("Loaded .env file from: %s\n", envFile)
+			logger.Default().Debug("Loaded .env file", "path", envFile)
 		}
 	} else {
-		fmt.Printf("No .env file found in current directory or parent directories\n")
+		logger.Default().Debug("No .env file found in current directory or parent directories")
 	}
 }
 
@@ -129,6 +131,7 @@ func LoadConfig(cfg *Config, customEnvPath string) Config {
 		return Config{
 			Endpoint:  cfg.Endpoint,
 			TimeoutMs: cfg.TimeoutMs,
+			Retry:     cfg.Retry,
 		}
 	}
 
@@ -144,7 +147,8 @@ func LoadConfig(cfg *Config, customEnvPath string) Config {
 	if timeoutMS := os.Getenv("AGENTBAY_TIMEOUT_MS"); timeoutMS != "" {
 		_, err := fmt.Sscanf(timeoutMS, "%d", &config.TimeoutMs)
 		if err != nil {
-			fmt.Printf("Warning: Failed to parse AGENTBAY_TIMEOUT_MS as integer: %v, using default value %d\n", err, config.TimeoutMs)
+			logger.Default().Warn("Failed to parse AGENTBAY_TIMEOUT_MS as integer, using default value",
+				logger.KeyError, err, "timeout_ms", config.TimeoutMs)
 		}
 	}
 
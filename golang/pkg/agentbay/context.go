@@ -1,8 +1,11 @@
 package agentbay
 
 import (
+	"context"
 	"fmt"
+	"time"
 
+	"github.com/alibabacloud-go/tea/dara"
 	"github.com/alibabacloud-go/tea/tea"
 	mcp "github.com/aliyun/wuying-agentbay-sdk/golang/api/client"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
@@ -108,33 +111,34 @@ func (cs *ContextService) List(params *ContextListParams) (*ContextListResult, e
 	}
 
 	// Log API request
-	fmt.Println("API Call: ListContexts")
-	fmt.Printf("Request: MaxResults=%d", *request.MaxResults)
-	if request.NextToken != nil {
-		fmt.Printf(", NextToken=%s", *request.NextToken)
-	}
-	fmt.Println()
+	log := cs.AgentBay.GetLogger()
+	logAPIRequest(log, "ListContexts", "", "max_results", *request.MaxResults, "next_token", tea.StringValue(request.NextToken))
+
+	start := time.Now()
 
-	response, err := cs.AgentBay.Client.ListContexts(request)
+	response, retries, err := invokeWithRetry(context.Background(), cs.AgentBay.GetRetryPolicy(), log, "ListContexts", func(runtime *dara.RuntimeOptions) (*mcp.ListContextsResponse, error) {
+		return cs.AgentBay.apiClient().ListContextsWithOptions(request, runtime)
+	})
 
 	// Extract RequestID
 	requestID := models.ExtractRequestID(response)
 
 	// Log API response
 	if err != nil {
-		fmt.Println("Error calling ListContexts:", err)
+		logAPIError(log, "ListContexts", "", start, err)
 		return &ContextListResult{
 			ApiResponse: models.ApiResponse{
-				RequestID: requestID,
+				RequestID:  requestID,
+				RetryCount: retries,
 			},
 			Success:      false,
 			Contexts:     []*Context{},
 			ErrorMessage: fmt.Sprintf("Failed to list contexts: %v", err),
-		}, nil
+		}, newAPIError("ListContexts", err)
 	}
 
 	if response != nil && response.Body != nil {
-		fmt.Println("Response from ListContexts:", response.Body)
+		logAPIResponse(log, "ListContexts", "", requestID, start, response.Body)
 	}
 
 	// Check for API-level errors
@@ -148,12 +152,13 @@ func (cs *ContextService) List(params *ContextListParams) (*ContextListResult, e
 			}
 			return &ContextListResult{
 				ApiResponse: models.ApiResponse{
-					RequestID: requestID,
+					RequestID:  requestID,
+					RetryCount: retries,
 				},
 				Success:      false,
 				Contexts:     []*Context{},
 				ErrorMessage: errorMsg,
-			}, nil
+			}, newAPIResponseError("ListContexts", tea.StringValue(response.Body.Code), tea.StringValue(response.Body.Message), requestID)
 		}
 	}
 
@@ -191,7 +196,8 @@ func (cs *ContextService) List(params *ContextListParams) (*ContextListResult, e
 
 	return &ContextListResult{
 		ApiResponse: models.ApiResponse{
-			RequestID: requestID,
+			RequestID:  requestID,
+			RetryCount: retries,
 		},
 		Success:      true,
 		Contexts:     contexts,
@@ -211,30 +217,35 @@ func (cs *ContextService) Get(name string, create bool) (*ContextResult, error)
 	}
 
 	// Log API request
-	fmt.Println("API Call: GetContext")
-	fmt.Printf("Request: Name=%s, AllowCreate=%t\n", *request.Name, *request.AllowCreate)
+	log := cs.AgentBay.GetLogger()
+	logAPIRequest(log, "GetContext", "", "name", name, "allow_create", create)
 
-	response, err := cs.AgentBay.Client.GetContext(request)
+	start := time.Now()
+
+	response, retries, err := invokeWithRetry(context.Background(), cs.AgentBay.GetRetryPolicy(), log, "GetContext", func(runtime *dara.RuntimeOptions) (*mcp.GetContextResponse, error) {
+		return cs.AgentBay.apiClient().GetContextWithOptions(request, runtime)
+	})
 
 	// Extract RequestID
 	requestID := models.ExtractRequestID(response)
 
 	// Log API response
 	if err != nil {
-		fmt.Println("Error calling GetContext:", err)
+		logAPIError(log, "GetContext", "", start, err)
 		return &ContextResult{
 			ApiResponse: models.ApiResponse{
-				RequestID: requestID,
+				RequestID:  requestID,
+				RetryCount: retries,
 			},
 			Success:      false,
 			ContextID:    "",
 			Context:      nil,
 			ErrorMessage: fmt.Sprintf("Failed to get context %s: %v", name, err),
-		}, nil
+		}, newAPIError("GetContext", err)
 	}
 
 	if response != nil && response.Body != nil {
-		fmt.Println("Response from GetContext:", response.Body)
+		logAPIResponse(log, "GetContext", "", requestID, start, response.Body)
 	}
 
 	// Check for API-level errors
@@ -248,26 +259,28 @@ func (cs *ContextService) Get(name string, create bool) (*ContextResult, error)
 			}
 			return &ContextResult{
 				ApiResponse: models.ApiResponse{
-					RequestID: requestID,
+					RequestID:  requestID,
+					RetryCount: retries,
 				},
 				Success:      false,
 				ContextID:    "",
 				Context:      nil,
 				ErrorMessage: errorMsg,
-			}, nil
+			}, newAPIResponseError("GetContext", tea.StringValue(response.Body.Code), tea.StringValue(response.Body.Message), requestID)
 		}
 	}
 
 	if response.Body == nil || response.Body.Data == nil || response.Body.Data.Id == nil {
 		return &ContextResult{
 			ApiResponse: models.ApiResponse{
-				RequestID: requestID,
+				RequestID:  requestID,
+				RetryCount: retries,
 			},
 			Success:      false,
 			ContextID:    "",
 			Context:      nil,
 			ErrorMessage: "Context ID not found in response",
-		}, nil
+		}, &APIError{API: "GetContext", Message: "context ID not found in response", RequestID: requestID}
 	}
 
 	// Create context object
@@ -282,7 +295,8 @@ func (cs *ContextService) Get(name string, create bool) (*ContextResult, error)
 
 	return &ContextResult{
 		ApiResponse: models.ApiResponse{
-			RequestID: requestID,
+			RequestID:  requestID,
+			RetryCount: retries,
 		},
 		Success:      true,
 		ContextID:    tea.StringValue(response.Body.Data.Id),
@@ -316,30 +330,34 @@ func (cs *ContextService) Create(name string) (*ContextCreateResult, error) {
 
 // Update updates the specified context.
 // Returns a result with success status.
-func (cs *ContextService) Update(context *Context) (*ContextModifyResult, error) {
+func (cs *ContextService) Update(c *Context) (*ContextModifyResult, error) {
 	request := &mcp.ModifyContextRequest{
-		Id:            tea.String(context.ID),
-		Name:          tea.String(context.Name),
+		Id:            tea.String(c.ID),
+		Name:          tea.String(c.Name),
 		Authorization: tea.String("Bearer " + cs.AgentBay.APIKey),
 	}
 
 	// Log API request
-	fmt.Println("API Call: ModifyContext")
-	fmt.Printf("Request: Id=%s, Name=%s\n", *request.Id, *request.Name)
+	log := cs.AgentBay.GetLogger()
+	logAPIRequest(log, "ModifyContext", "", "context_id", *request.Id, "name", *request.Name)
+
+	start := time.Now()
 
-	response, err := cs.AgentBay.Client.ModifyContext(request)
+	response, retries, err := invokeWithRetry(context.Background(), cs.AgentBay.GetRetryPolicy(), log, "ModifyContext", func(runtime *dara.RuntimeOptions) (*mcp.ModifyContextResponse, error) {
+		return cs.AgentBay.apiClient().ModifyContextWithOptions(request, runtime)
+	})
 
 	// Log API response
 	if err != nil {
-		fmt.Println("Error calling ModifyContext:", err)
-		return nil, fmt.Errorf("failed to update context %s: %v", context.ID, err)
+		logAPIError(log, "ModifyContext", "", start, err)
+		return nil, fmt.Errorf("failed to update context %s: %w", c.ID, newAPIError("ModifyContext", err))
 	}
 
 	// Extract RequestID
 	requestID := models.ExtractRequestID(response)
 
 	if response != nil && response.Body != nil {
-		fmt.Println("Response from ModifyContext:", response.Body)
+		logAPIResponse(log, "ModifyContext", "", requestID, start, response.Body)
 	}
 
 	// Check for API-level errors
@@ -353,46 +371,52 @@ func (cs *ContextService) Update(context *Context) (*ContextModifyResult, error)
 			}
 			return &ContextModifyResult{
 				ApiResponse: models.ApiResponse{
-					RequestID: requestID,
+					RequestID:  requestID,
+					RetryCount: retries,
 				},
 				Success:      false,
 				ErrorMessage: errorMsg,
-			}, nil
+			}, newAPIResponseError("ModifyContext", tea.StringValue(response.Body.Code), tea.StringValue(response.Body.Message), requestID)
 		}
 	}
 
 	return &ContextModifyResult{
 		ApiResponse: models.ApiResponse{
-			RequestID: requestID,
+			RequestID:  requestID,
+			RetryCount: retries,
 		},
 		Success: true,
 	}, nil
 }
 
 // Delete deletes the specified context.
-func (cs *ContextService) Delete(context *Context) (*ContextDeleteResult, error) {
+func (cs *ContextService) Delete(c *Context) (*ContextDeleteResult, error) {
 	request := &mcp.DeleteContextRequest{
-		Id:            tea.String(context.ID),
+		Id:            tea.String(c.ID),
 		Authorization: tea.String("Bearer " + cs.AgentBay.APIKey),
 	}
 
 	// Log API request
-	fmt.Println("API Call: DeleteContext")
-	fmt.Printf("Request: Id=%s\n", *request.Id)
+	log := cs.AgentBay.GetLogger()
+	logAPIRequest(log, "DeleteContext", "", "context_id", *request.Id)
 
-	response, err := cs.AgentBay.Client.DeleteContext(request)
+	start := time.Now()
+
+	response, retries, err := invokeWithRetry(context.Background(), cs.AgentBay.GetRetryPolicy(), log, "DeleteContext", func(runtime *dara.RuntimeOptions) (*mcp.DeleteContextResponse, error) {
+		return cs.AgentBay.apiClient().DeleteContextWithOptions(request, runtime)
+	})
 
 	// Log API response
 	if err != nil {
-		fmt.Println("Error calling DeleteContext:", err)
-		return nil, fmt.Errorf("failed to delete context %s: %v", context.ID, err)
+		logAPIError(log, "DeleteContext", "", start, err)
+		return nil, fmt.Errorf("failed to delete context %s: %w", c.ID, newAPIError("DeleteContext", err))
 	}
 
 	// Extract RequestID
 	requestID := models.ExtractRequestID(response)
 
 	if response != nil && response.Body != nil {
-		fmt.Println("Response from DeleteContext:", response.Body)
+		logAPIResponse(log, "DeleteContext", "", requestID, start, response.Body)
 	}
 
 	// Check for API-level errors
@@ -406,17 +430,19 @@ func (cs *ContextService) Delete(context *Context) (*ContextDeleteResult, error)
 			}
 			return &ContextDeleteResult{
 				ApiResponse: models.ApiResponse{
-					RequestID: requestID,
+					RequestID:  requestID,
+					RetryCount: retries,
 				},
 				Success:      false,
 				ErrorMessage: errorMsg,
-			}, nil
+			}, newAPIResponseError("DeleteContext", tea.StringValue(response.Body.Code), tea.StringValue(response.Body.Message), requestID)
 		}
 	}
 
 	return &ContextDeleteResult{
 		ApiResponse: models.ApiResponse{
-			RequestID: requestID,
+			RequestID:  requestID,
+			RetryCount: retries,
 		},
 		Success: true,
 	}, nil
@@ -467,13 +493,17 @@ func (cs *ContextService) GetFileDownloadUrl(contextID string, filePath string)
 		FilePath:      tea.String(filePath),
 	}
 
-	fmt.Println("API Call: GetContextFileDownloadUrl")
-	fmt.Printf("Request: ContextId=%s, FilePath=%s\n", contextID, filePath)
+	log := cs.AgentBay.GetLogger()
+	logAPIRequest(log, "GetContextFileDownloadUrl", "", "context_id", contextID, "file_path", filePath)
+
+	start := time.Now()
 
-	resp, err := cs.AgentBay.Client.GetContextFileDownloadUrl(req)
+	resp, retries, err := invokeWithRetry(context.Background(), cs.AgentBay.GetRetryPolicy(), log, "GetContextFileDownloadUrl", func(runtime *dara.RuntimeOptions) (*mcp.GetContextFileDownloadUrlResponse, error) {
+		return cs.AgentBay.apiClient().GetContextFileDownloadUrlWithOptions(req, runtime)
+	})
 	if err != nil {
-		fmt.Println("Error calling GetContextFileDownloadUrl:", err)
-		return nil, err
+		logAPIError(log, "GetContextFileDownloadUrl", "", start, err)
+		return nil, newAPIError("GetContextFileDownloadUrl", err)
 	}
 
 	requestID := models.ExtractRequestID(resp)
@@ -497,12 +527,12 @@ func (cs *ContextService) GetFileDownloadUrl(contextID string, filePath string)
 			}
 			errorMessage = fmt.Sprintf("[%s] %s", code, message)
 			return &ContextFileUrlResult{
-				ApiResponse:  models.WithRequestID(requestID),
+				ApiResponse:  models.ApiResponse{RequestID: requestID, RetryCount: retries},
 				Success:      false,
 				Url:          "",
 				ExpireTime:   nil,
 				ErrorMessage: errorMessage,
-			}, nil
+			}, newAPIResponseError("GetContextFileDownloadUrl", code, message, requestID)
 		}
 
 		if resp.Body.Data != nil {
@@ -513,11 +543,11 @@ func (cs *ContextService) GetFileDownloadUrl(contextID string, filePath string)
 				expire = resp.Body.Data.ExpireTime
 			}
 		}
-		fmt.Println("Response from GetContextFileDownloadUrl:", resp.Body)
+		logAPIResponse(log, "GetContextFileDownloadUrl", "", requestID, start, resp.Body)
 	}
 
 	return &ContextFileUrlResult{
-		ApiResponse:  models.WithRequestID(requestID),
+		ApiResponse:  models.ApiResponse{RequestID: requestID, RetryCount: retries},
 		Success:      success,
 		Url:          url,
 		ExpireTime:   expire,
@@ -533,13 +563,17 @@ func (cs *ContextService) GetFileUploadUrl(contextID string, filePath string) (*
 		FilePath:      tea.String(filePath),
 	}
 
-	fmt.Println("API Call: GetContextFileUploadUrl")
-	fmt.Printf("Request: ContextId=%s, FilePath=%s\n", contextID, filePath)
+	log := cs.AgentBay.GetLogger()
+	logAPIRequest(log, "GetContextFileUploadUrl", "", "context_id", contextID, "file_path", filePath)
 
-	resp, err := cs.AgentBay.Client.GetContextFileUploadUrl(req)
+	start := time.Now()
+
+	resp, retries, err := invokeWithRetry(context.Background(), cs.AgentBay.GetRetryPolicy(), log, "GetContextFileUploadUrl", func(runtime *dara.RuntimeOptions) (*mcp.GetContextFileUploadUrlResponse, error) {
+		return cs.AgentBay.apiClient().GetContextFileUploadUrlWithOptions(req, runtime)
+	})
 	if err != nil {
-		fmt.Println("Error calling GetContextFileUploadUrl:", err)
-		return nil, err
+		logAPIError(log, "GetContextFileUploadUrl", "", start, err)
+		return nil, newAPIError("GetContextFileUploadUrl", err)
 	}
 
 	requestID := models.ExtractRequestID(resp)
@@ -563,12 +597,12 @@ func (cs *ContextService) GetFileUploadUrl(contextID string, filePath string) (*
 			}
 			errorMessage = fmt.Sprintf("[%s] %s", code, message)
 			return &ContextFileUrlResult{
-				ApiResponse:  models.WithRequestID(requestID),
+				ApiResponse:  models.ApiResponse{RequestID: requestID, RetryCount: retries},
 				Success:      false,
 				Url:          "",
 				ExpireTime:   nil,
 				ErrorMessage: errorMessage,
-			}, nil
+			}, newAPIResponseError("GetContextFileUploadUrl", code, message, requestID)
 		}
 
 		if resp.Body.Data != nil {
@@ -579,11 +613,11 @@ func (cs *ContextService) GetFileUploadUrl(contextID string, filePath string) (*
 				expire = resp.Body.Data.ExpireTime
 			}
 		}
-		fmt.Println("Response from GetContextFileUploadUrl:", resp.Body)
+		logAPIResponse(log, "GetContextFileUploadUrl", "", requestID, start, resp.Body)
 	}
 
 	return &ContextFileUrlResult{
-		ApiResponse:  models.WithRequestID(requestID),
+		ApiResponse:  models.ApiResponse{RequestID: requestID, RetryCount: retries},
 		Success:      success,
 		Url:          url,
 		ExpireTime:   expire,
@@ -601,13 +635,18 @@ func (cs *ContextService) ListFiles(contextID string, parentFolderPath string, p
 		ContextId:        tea.String(contextID),
 	}
 
-	fmt.Println("API Call: DescribeContextFiles")
-	fmt.Printf("Request: ContextId=%s, ParentFolderPath=%s, PageNumber=%d, PageSize=%d\n", contextID, parentFolderPath, pageNumber, pageSize)
+	log := cs.AgentBay.GetLogger()
+	logAPIRequest(log, "DescribeContextFiles", "", "context_id", contextID, "parent_folder_path", parentFolderPath,
+		"page_number", pageNumber, "page_size", pageSize)
 
-	resp, err := cs.AgentBay.Client.DescribeContextFiles(req)
+	start := time.Now()
+
+	resp, retries, err := invokeWithRetry(context.Background(), cs.AgentBay.GetRetryPolicy(), log, "DescribeContextFiles", func(runtime *dara.RuntimeOptions) (*mcp.DescribeContextFilesResponse, error) {
+		return cs.AgentBay.apiClient().DescribeContextFilesWithOptions(req, runtime)
+	})
 	if err != nil {
-		fmt.Println("Error calling DescribeContextFiles:", err)
-		return nil, err
+		logAPIError(log, "DescribeContextFiles", "", start, err)
+		return nil, newAPIError("DescribeContextFiles", err)
 	}
 
 	requestID := models.ExtractRequestID(resp)
@@ -631,12 +670,12 @@ func (cs *ContextService) ListFiles(contextID string, parentFolderPath string, p
 			}
 			errorMessage = fmt.Sprintf("[%s] %s", code, message)
 			return &ContextFileListResult{
-				ApiResponse:  models.WithRequestID(requestID),
+				ApiResponse:  models.ApiResponse{RequestID: requestID, RetryCount: retries},
 				Success:      false,
 				Entries:      []*ContextFileEntry{},
 				Count:        nil,
 				ErrorMessage: errorMessage,
-			}, nil
+			}, newAPIResponseError("DescribeContextFiles", code, message, requestID)
 		}
 
 		if resp.Body.Count != nil {
@@ -673,11 +712,11 @@ func (cs *ContextService) ListFiles(contextID string, parentFolderPath string, p
 			}
 			entries = append(entries, entry)
 		}
-		fmt.Println("Response from DescribeContextFiles:", resp.Body)
+		logAPIResponse(log, "DescribeContextFiles", "", requestID, start, resp.Body)
 	}
 
 	return &ContextFileListResult{
-		ApiResponse:  models.WithRequestID(requestID),
+		ApiResponse:  models.ApiResponse{RequestID: requestID, RetryCount: retries},
 		Success:      success,
 		Entries:      entries,
 		Count:        count,
@@ -693,18 +732,23 @@ func (cs *ContextService) DeleteFile(contextID string, filePath string) (*Contex
 		FilePath:      tea.String(filePath),
 	}
 
-	fmt.Println("API Call: DeleteContextFile")
-	fmt.Printf("Request: ContextId=%s, FilePath=%s\n", contextID, filePath)
+	log := cs.AgentBay.GetLogger()
+	logAPIRequest(log, "DeleteContextFile", "", "context_id", contextID, "file_path", filePath)
 
-	resp, err := cs.AgentBay.Client.DeleteContextFile(req)
+	start := time.Now()
+
+	resp, retries, err := invokeWithRetry(context.Background(), cs.AgentBay.GetRetryPolicy(), log, "DeleteContextFile", func(runtime *dara.RuntimeOptions) (*mcp.DeleteContextFileResponse, error) {
+		return cs.AgentBay.apiClient().DeleteContextFileWithOptions(req, runtime)
+	})
 	if err != nil {
-		fmt.Println("Error calling DeleteContextFile:", err)
-		return nil, err
+		logAPIError(log, "DeleteContextFile", "", start, err)
+		return nil, newAPIError("DeleteContextFile", err)
 	}
 
 	requestID := models.ExtractRequestID(resp)
 	success := false
 	var errorMessage string
+	var apiErr error
 
 	if resp != nil && resp.Body != nil {
 		if resp.Body.Success != nil {
@@ -719,14 +763,15 @@ func (cs *ContextService) DeleteFile(contextID string, filePath string) (*Contex
 				message = "Failed to delete file"
 			}
 			errorMessage = fmt.Sprintf("[%s] %s", code, message)
+			apiErr = newAPIResponseError("DeleteContextFile", code, message, requestID)
 		}
 
-		fmt.Println("Response from DeleteContextFile:", resp.Body)
+		logAPIResponse(log, "DeleteContextFile", "", requestID, start, resp.Body)
 	}
 
 	return &ContextFileDeleteResult{
-		ApiResponse:  models.WithRequestID(requestID),
+		ApiResponse:  models.ApiResponse{RequestID: requestID, RetryCount: retries},
 		Success:      success,
 		ErrorMessage: errorMessage,
-	}, nil
+	}, apiErr
 }
@@ -1,12 +1,15 @@
 package agentbay
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/alibabacloud-go/tea/dara"
 	"github.com/alibabacloud-go/tea/tea"
 	mcp "github.com/aliyun/wuying-agentbay-sdk/golang/api/client"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/logger"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
 )
 
@@ -65,13 +68,24 @@ func NewContextManager(session interface {
 	}
 }
 
+// logger returns the logger of the owning session, falling back to logger.Default()
+func (cm *ContextManager) logger() logger.Logger {
+	return logger.From(cm.Session)
+}
+
 // Info retrieves context information for the current session.
 func (cm *ContextManager) Info() (*ContextInfoResult, error) {
 	return cm.InfoWithParams("", "", "")
 }
 
 // InfoWithParams retrieves context information for the current session with optional parameters.
+// If the API reports an error, the failed result is returned together with an *APIError.
 func (cm *ContextManager) InfoWithParams(contextId, path, taskType string) (*ContextInfoResult, error) {
+	return cm.InfoWithContext(context.Background(), contextId, path, taskType)
+}
+
+// InfoWithContext is like InfoWithParams but honours the cancellation and deadline of ctx.
+func (cm *ContextManager) InfoWithContext(ctx context.Context, contextId, path, taskType string) (*ContextInfoResult, error) {
 	request := &mcp.GetContextInfoRequest{
 		Authorization: tea.String("Bearer " + cm.Session.GetAPIKey()),
 		SessionId:     tea.String(cm.Session.GetSessionId()),
@@ -89,32 +103,27 @@ func (cm *ContextManager) InfoWithParams(contextId, path, taskType string) (*Con
 	}
 
 	// Log API request
-	fmt.Println("API Call: GetContextInfo")
-	fmt.Printf("Request: SessionId=%s", *request.SessionId)
-	if request.ContextId != nil {
-		fmt.Printf(", ContextId=%s", *request.ContextId)
-	}
-	if request.Path != nil {
-		fmt.Printf(", Path=%s", *request.Path)
-	}
-	if request.TaskType != nil {
-		fmt.Printf(", TaskType=%s", *request.TaskType)
-	}
-	fmt.Println()
+	log := cm.logger()
+	sessionID := cm.Session.GetSessionId()
+	logAPIRequest(log, "GetContextInfo", sessionID, "context_id", contextId, "path", path, "task_type", taskType)
 
-	response, err := cm.Session.GetClient().GetContextInfo(request)
+	start := time.Now()
+
+	response, retries, err := invokeWithRetry(ctx, retryPolicyFrom(cm.Session), log, "GetContextInfo", func(runtime *dara.RuntimeOptions) (*mcp.GetContextInfoResponse, error) {
+		return cm.Session.GetClient().GetContextInfoWithOptions(request, runtime)
+	})
 
 	// Log API response
 	if err != nil {
-		fmt.Println("Error calling GetContextInfo:", err)
-		return nil, fmt.Errorf("failed to get context info: %w", err)
+		logAPIError(log, "GetContextInfo", sessionID, start, err)
+		return nil, fmt.Errorf("failed to get context info: %w", sessionError(sessionID, newAPIError("GetContextInfo", err)))
 	}
 
 	// Extract RequestID
 	requestID := models.ExtractRequestID(response)
 
 	if response != nil && response.Body != nil {
-		fmt.Println("Response from GetContextInfo:", response.Body)
+		logAPIResponse(log, "GetContextInfo", sessionID, requestID, start, response.Body)
 	}
 
 	// Check for API-level errors
@@ -127,12 +136,13 @@ func (cm *ContextManager) InfoWithParams(contextId, path, taskType string) (*Con
 			}
 			return &ContextInfoResult{
 				ApiResponse: models.ApiResponse{
-					RequestID: requestID,
+					RequestID:  requestID,
+					RetryCount: retries,
 				},
 				Success:           false,
 				ContextStatusData: []ContextStatusData{},
 				ErrorMessage:      fmt.Sprintf("[%s] %s", code, message),
-			}, nil
+			}, sessionError(sessionID, newAPIResponseError("GetContextInfo", code, message, requestID))
 		}
 	}
 
@@ -144,7 +154,7 @@ func (cm *ContextManager) InfoWithParams(contextId, path, taskType string) (*Con
 			// First, parse the outer array
 			var statusItems []ContextStatusItem
 			if err := json.Unmarshal([]byte(contextStatus), &statusItems); err != nil {
-				fmt.Println("Error parsing context status:", err)
+				log.Error("Failed to parse context status", logger.KeySessionID, sessionID, logger.KeyError, err)
 			} else {
 				// Process each item in the array
 				for _, item := range statusItems {
@@ -152,7 +162,7 @@ func (cm *ContextManager) InfoWithParams(contextId, path, taskType string) (*Con
 						// Parse the inner data string
 						var dataItems []ContextStatusData
 						if err := json.Unmarshal([]byte(item.Data), &dataItems); err != nil {
-							fmt.Println("Error parsing context status data:", err)
+							log.Error("Failed to parse context status data", logger.KeySessionID, sessionID, logger.KeyError, err)
 						} else {
 							contextStatusData = append(contextStatusData, dataItems...)
 						}
@@ -164,7 +174,8 @@ func (cm *ContextManager) InfoWithParams(contextId, path, taskType string) (*Con
 
 	return &ContextInfoResult{
 		ApiResponse: models.ApiResponse{
-			RequestID: requestID,
+			RequestID:  requestID,
+			RetryCount: retries,
 		},
 		Success:           true,
 		ContextStatusData: contextStatusData,
@@ -181,10 +192,17 @@ func (cm *ContextManager) Sync() (*ContextSyncResult, error) {
 // If callback is provided, it runs in background and calls callback when complete.
 // If callback is nil, it waits for completion before returning.
 func (cm *ContextManager) SyncWithCallback(contextId, path, mode string, callback SyncCallback, maxRetries int, retryInterval int) (*ContextSyncResult, error) {
+	return cm.SyncWithCallbackContext(context.Background(), contextId, path, mode, callback, maxRetries, retryInterval)
+}
+
+// SyncWithCallbackContext is like SyncWithCallback but stops polling when ctx is done.
+// In callback mode the background poller invokes callback(false) if ctx ends before the
+// sync completes; in sync mode ctx.Err() is returned.
+func (cm *ContextManager) SyncWithCallbackContext(ctx context.Context, contextId, path, mode string, callback SyncCallback, maxRetries int, retryInterval int) (*ContextSyncResult, error) {
 	// First, trigger the sync operation
-	syncResult, err := cm.SyncWithParams(contextId, path, mode)
+	syncResult, err := cm.SyncWithContext(ctx, contextId, path, mode)
 	if err != nil {
-		return nil, err
+		return syncResult, err
 	}
 
 	// If sync failed, return immediately
@@ -194,12 +212,12 @@ func (cm *ContextManager) SyncWithCallback(contextId, path, mode string, callbac
 
 	// If callback is provided, start polling in background (async mode)
 	if callback != nil {
-		go cm.pollForCompletion(callback, contextId, path, maxRetries, retryInterval)
+		go cm.pollForCompletion(ctx, callback, contextId, path, maxRetries, retryInterval)
 		return syncResult, nil
 	}
 
 	// If no callback, wait for completion (sync mode)
-	finalSuccess, err := cm.pollForCompletionSync(contextId, path, maxRetries, retryInterval)
+	finalSuccess, err := cm.pollForCompletionSync(ctx, contextId, path, maxRetries, retryInterval)
 	if err != nil {
 		return nil, err
 	}
@@ -211,7 +229,13 @@ func (cm *ContextManager) SyncWithCallback(contextId, path, mode string, callbac
 }
 
 // SyncWithParams synchronizes the context for the current session with optional parameters.
+// If the API reports an error, the failed result is returned together with an *APIError.
 func (cm *ContextManager) SyncWithParams(contextId, path, mode string) (*ContextSyncResult, error) {
+	return cm.SyncWithContext(context.Background(), contextId, path, mode)
+}
+
+// SyncWithContext is like SyncWithParams but honours the cancellation and deadline of ctx.
+func (cm *ContextManager) SyncWithContext(ctx context.Context, contextId, path, mode string) (*ContextSyncResult, error) {
 	request := &mcp.SyncContextRequest{
 		Authorization: tea.String("Bearer " + cm.Session.GetAPIKey()),
 		SessionId:     tea.String(cm.Session.GetSessionId()),
@@ -231,31 +255,27 @@ func (cm *ContextManager) SyncWithParams(contextId, path, mode string) (*Context
 	request.Mode = tea.String(mode)
 
 	// Log API request
-	requestInfo := fmt.Sprintf("API Call: SyncContext - Request: SessionId=%s", *request.SessionId)
-	if request.ContextId != nil {
-		requestInfo += fmt.Sprintf(", ContextId=%s", *request.ContextId)
-	}
-	if request.Path != nil {
-		requestInfo += fmt.Sprintf(", Path=%s", *request.Path)
-	}
-	if request.Mode != nil {
-		requestInfo += fmt.Sprintf(", Mode=%s", *request.Mode)
-	}
-	fmt.Println(requestInfo)
+	log := cm.logger()
+	sessionID := cm.Session.GetSessionId()
+	logAPIRequest(log, "SyncContext", sessionID, "context_id", contextId, "path", path, "mode", mode)
 
-	response, err := cm.Session.GetClient().SyncContext(request)
+	start := time.Now()
+
+	response, retries, err := invokeWithRetry(ctx, retryPolicyFrom(cm.Session), log, "SyncContext", func(runtime *dara.RuntimeOptions) (*mcp.SyncContextResponse, error) {
+		return cm.Session.GetClient().SyncContextWithOptions(request, runtime)
+	})
 
 	// Log API response
 	if err != nil {
-		fmt.Println("Error calling SyncContext:", err)
-		return nil, fmt.Errorf("failed to sync context: %w", err)
+		logAPIError(log, "SyncContext", sessionID, start, err)
+		return nil, fmt.Errorf("failed to sync context: %w", sessionError(sessionID, newAPIError("SyncContext", err)))
 	}
 
 	// Extract RequestID
 	requestID := models.ExtractRequestID(response)
 
 	if response != nil && response.Body != nil {
-		fmt.Println("Response from SyncContext:", response.Body)
+		logAPIResponse(log, "SyncContext", sessionID, requestID, start, response.Body)
 	}
 
 	// Check for API-level errors
@@ -268,11 +288,12 @@ func (cm *ContextManager) SyncWithParams(contextId, path, mode string) (*Context
 			}
 			return &ContextSyncResult{
 				ApiResponse: models.ApiResponse{
-					RequestID: requestID,
+					RequestID:  requestID,
+					RetryCount: retries,
 				},
 				Success:      false,
 				ErrorMessage: fmt.Sprintf("[%s] %s", code, message),
-			}, nil
+			}, sessionError(sessionID, newAPIResponseError("SyncContext", code, message, requestID))
 		}
 	}
 
@@ -283,7 +304,8 @@ func (cm *ContextManager) SyncWithParams(contextId, path, mode string) (*Context
 
 	return &ContextSyncResult{
 		ApiResponse: models.ApiResponse{
-			RequestID: requestID,
+			RequestID:  requestID,
+			RetryCount: retries,
 		},
 		Success:      success,
 		ErrorMessage: "",
@@ -291,13 +313,25 @@ func (cm *ContextManager) SyncWithParams(contextId, path, mode string) (*Context
 }
 
 // pollForCompletion polls the info interface to check if sync is completed and calls callback.
-func (cm *ContextManager) pollForCompletion(callback SyncCallback, contextId, path string, maxRetries, retryInterval int) {
+func (cm *ContextManager) pollForCompletion(ctx context.Context, callback SyncCallback, contextId, path string, maxRetries, retryInterval int) {
+	log := cm.logger()
+	sessionID := cm.Session.GetSessionId()
+
 	for retry := 0; retry < maxRetries; retry++ {
 		// Get context status data
-		infoResult, err := cm.InfoWithParams(contextId, path, "")
-		if err != nil {
-			fmt.Printf("Error checking context status on attempt %d: %v\n", retry+1, err)
-			time.Sleep(time.Duration(retryInterval) * time.Millisecond)
+		infoResult, err := cm.InfoWithContext(ctx, contextId, path, "")
+		if err != nil && infoResult == nil {
+			if ctx.Err() != nil {
+				log.Warn("Context sync polling cancelled", logger.KeySessionID, sessionID, logger.KeyError, ctx.Err())
+				callback(false)
+				return
+			}
+			log.Warn("Failed to check context status", logger.KeySessionID, sessionID, "attempt", retry+1, logger.KeyError, err)
+			if sleepWithContext(ctx, time.Duration(retryInterval)*time.Millisecond) != nil {
+				log.Warn("Context sync polling cancelled", logger.KeySessionID, sessionID, logger.KeyError, ctx.Err())
+				callback(false)
+				return
+			}
 			continue
 		}
 
@@ -313,7 +347,7 @@ func (cm *ContextManager) pollForCompletion(callback SyncCallback, contextId, pa
 			}
 
 			hasSyncTasks = true
-			fmt.Printf("Sync task %s status: %s, path: %s\n", item.ContextId, item.Status, item.Path)
+			log.Debug("Sync task status", logger.KeySessionID, sessionID, "context_id", item.ContextId, "status", item.Status, "path", item.Path)
 
 			if item.Status != "Success" && item.Status != "Failed" {
 				allCompleted = false
@@ -322,42 +356,54 @@ func (cm *ContextManager) pollForCompletion(callback SyncCallback, contextId, pa
 
 			if item.Status == "Failed" {
 				hasFailure = true
-				fmt.Printf("Sync failed for context %s: %s\n", item.ContextId, item.ErrorMessage)
+				log.Error("Sync failed for context", logger.KeySessionID, sessionID, "context_id", item.ContextId, logger.KeyError, item.ErrorMessage)
 			}
 		}
 
 		if allCompleted || !hasSyncTasks {
 			// All tasks completed or no sync tasks found
 			if hasFailure {
-				fmt.Println("Context sync completed with failures")
+				log.Warn("Context sync completed with failures", logger.KeySessionID, sessionID)
 				callback(false)
 			} else if hasSyncTasks {
-				fmt.Println("Context sync completed successfully")
+				log.Info("Context sync completed successfully", logger.KeySessionID, sessionID)
 				callback(true)
 			} else {
-				fmt.Println("No sync tasks found")
+				log.Info("No sync tasks found", logger.KeySessionID, sessionID)
 				callback(true)
 			}
 			return // Exit the function immediately after calling callback
 		}
 
-		fmt.Printf("Waiting for context sync to complete, attempt %d/%d\n", retry+1, maxRetries)
-		time.Sleep(time.Duration(retryInterval) * time.Millisecond)
+		log.Debug("Waiting for context sync to complete", logger.KeySessionID, sessionID, "attempt", retry+1, "max_attempts", maxRetries)
+		if sleepWithContext(ctx, time.Duration(retryInterval)*time.Millisecond) != nil {
+			log.Warn("Context sync polling cancelled", logger.KeySessionID, sessionID, logger.KeyError, ctx.Err())
+			callback(false)
+			return
+		}
 	}
 
 	// If we've exhausted all retries, call callback with failure
-	fmt.Printf("Context sync polling timed out after %d attempts\n", maxRetries)
+	log.Warn("Context sync polling timed out", logger.KeySessionID, sessionID, "attempts", maxRetries)
 	callback(false)
 }
 
 // pollForCompletionSync is the synchronous version of polling for sync completion.
-func (cm *ContextManager) pollForCompletionSync(contextId, path string, maxRetries, retryInterval int) (bool, error) {
+func (cm *ContextManager) pollForCompletionSync(ctx context.Context, contextId, path string, maxRetries, retryInterval int) (bool, error) {
+	log := cm.logger()
+	sessionID := cm.Session.GetSessionId()
+
 	for retry := 0; retry < maxRetries; retry++ {
 		// Get context status data
-		infoResult, err := cm.InfoWithParams(contextId, path, "")
-		if err != nil {
-			fmt.Printf("Error checking context status on attempt %d: %v\n", retry+1, err)
-			time.Sleep(time.Duration(retryInterval) * time.Millisecond)
+		infoResult, err := cm.InfoWithContext(ctx, contextId, path, "")
+		if err != nil && infoResult == nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return false, ctxErr
+			}
+			log.Warn("Failed to check context status", logger.KeySessionID, sessionID, "attempt", retry+1, logger.KeyError, err)
+			if err := sleepWithContext(ctx, time.Duration(retryInterval)*time.Millisecond); err != nil {
+				return false, err
+			}
 			continue
 		}
 
@@ -373,7 +419,7 @@ func (cm *ContextManager) pollForCompletionSync(contextId, path string, maxRetri
 			}
 
 			hasSyncTasks = true
-			fmt.Printf("Sync task %s status: %s, path: %s\n", item.ContextId, item.Status, item.Path)
+			log.Debug("Sync task status", logger.KeySessionID, sessionID, "context_id", item.ContextId, "status", item.Status, "path", item.Path)
 
 			if item.Status != "Success" && item.Status != "Failed" {
 				allCompleted = false
@@ -382,29 +428,31 @@ func (cm *ContextManager) pollForCompletionSync(contextId, path string, maxRetri
 
 			if item.Status == "Failed" {
 				hasFailure = true
-				fmt.Printf("Sync failed for context %s: %s\n", item.ContextId, item.ErrorMessage)
+				log.Error("Sync failed for context", logger.KeySessionID, sessionID, "context_id", item.ContextId, logger.KeyError, item.ErrorMessage)
 			}
 		}
 
 		if allCompleted || !hasSyncTasks {
 			// All tasks completed or no sync tasks found
 			if hasFailure {
-				fmt.Println("Context sync completed with failures")
+				log.Warn("Context sync completed with failures", logger.KeySessionID, sessionID)
 				return false, nil
 			} else if hasSyncTasks {
-				fmt.Println("Context sync completed successfully")
+				log.Info("Context sync completed successfully", logger.KeySessionID, sessionID)
 				return true, nil
 			} else {
-				fmt.Println("No sync tasks found")
+				log.Info("No sync tasks found", logger.KeySessionID, sessionID)
 				return true, nil
 			}
 		}
 
-		fmt.Printf("Waiting for context sync to complete, attempt %d/%d\n", retry+1, maxRetries)
-		time.Sleep(time.Duration(retryInterval) * time.Millisecond)
+		log.Debug("Waiting for context sync to complete", logger.KeySessionID, sessionID, "attempt", retry+1, "max_attempts", maxRetries)
+		if err := sleepWithContext(ctx, time.Duration(retryInterval)*time.Millisecond); err != nil {
+			return false, err
+		}
 	}
 
 	// If we've exhausted all retries, return failure
-	fmt.Printf("Context sync polling timed out after %d attempts\n", maxRetries)
+	log.Warn("Context sync polling timed out", logger.KeySessionID, sessionID, "attempts", maxRetries)
 	return false, nil
 }
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/filesystem"
 )
 
 // UploadStrategy defines the upload strategy for context synchronization
@@ -93,15 +95,9 @@ func NewDeletePolicy() *DeletePolicy {
 	}
 }
 
-// ExtractPolicy defines the extract policy for context synchronization
-type ExtractPolicy struct {
-	// Extract enables file extraction
-	Extract bool `json:"extract"`
-	// DeleteSrcFile enables deletion of source file after extraction
-	DeleteSrcFile bool `json:"deleteSrcFile"`
-	// ExtractToCurrentFolder enables extraction to current folder
-	ExtractToCurrentFolder bool `json:"extractToCurrentFolder"`
-}
+// ExtractPolicy defines the extract policy for context synchronization. The same policy is
+// used by FileSystem.Unpack and FileSystem.UploadArchive.
+type ExtractPolicy = filesystem.ExtractPolicy
 
 // NewExtractPolicy creates a new extract policy with default values
 func NewExtractPolicy() *ExtractPolicy {
@@ -0,0 +1,115 @@
+package agentbay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/alibabacloud-go/tea/tea"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+)
+
+// Typed errors returned by the SDK. They are defined in the models package so that the
+// service packages can return them too, and are re-exported here for convenience.
+type (
+	APIError             = models.APIError
+	SessionNotFoundError = models.SessionNotFoundError
+	ToolError            = models.ToolError
+	FileError            = models.FileError
+	CommandError         = models.CommandError
+	TimeoutError         = models.TimeoutError
+)
+
+var (
+	// ErrAuthentication is matched by errors.Is when the API key was rejected
+	ErrAuthentication = models.ErrAuthentication
+	// ErrSessionNotFound is matched by errors.Is when a session does not exist
+	ErrSessionNotFound = models.ErrSessionNotFound
+)
+
+// newAPIError converts an error returned by the OpenAPI client into an *APIError, extracting
+// the error code, HTTP status and request ID where available. Context errors are returned
+// unchanged and network timeouts are reported as *TimeoutError.
+func newAPIError(api string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	apiErr := &APIError{API: api, Err: err}
+
+	var teaErr *tea.SDKError
+	var daraErr *dara.SDKError
+	switch {
+	case errors.As(err, &teaErr):
+		apiErr.Code = tea.StringValue(teaErr.Code)
+		apiErr.Message = tea.StringValue(teaErr.Message)
+		apiErr.HTTPStatus = tea.IntValue(teaErr.StatusCode)
+		applyErrorData(apiErr, tea.StringValue(teaErr.Data))
+	case errors.As(err, &daraErr):
+		apiErr.Code = dara.StringValue(daraErr.Code)
+		apiErr.Message = dara.StringValue(daraErr.Message)
+		apiErr.HTTPStatus = dara.IntValue(daraErr.StatusCode)
+		applyErrorData(apiErr, dara.StringValue(daraErr.Data))
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = err.Error()
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return &TimeoutError{Op: api, Err: apiErr}
+	}
+	return apiErr
+}
+
+// newAPIResponseError creates an *APIError for an API call that returned an error code in its body
+func newAPIResponseError(api, code, message, requestID string) *APIError {
+	if message == "" {
+		message = "Unknown error"
+	}
+	return &APIError{
+		API:       api,
+		Code:      code,
+		Message:   message,
+		RequestID: requestID,
+	}
+}
+
+// applyErrorData fills in the message and request ID of apiErr from the JSON response body
+// attached to an SDK error, which is more precise than the formatted SDK error message.
+func applyErrorData(apiErr *APIError, data string) {
+	if data == "" {
+		return
+	}
+	var body struct {
+		Message   string
+		RequestId string
+	}
+	if err := json.Unmarshal([]byte(data), &body); err != nil {
+		return
+	}
+	if body.Message != "" {
+		apiErr.Message = body.Message
+	}
+	apiErr.RequestID = body.RequestId
+}
+
+// sessionError reports err as a *SessionNotFoundError when the API indicates that sessionID
+// does not exist, and returns it unchanged otherwise.
+func sessionError(sessionID string, err error) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	if apiErr.HTTPStatus == http.StatusNotFound || strings.Contains(strings.ToLower(apiErr.Code), "notfound") {
+		return &SessionNotFoundError{SessionID: sessionID, RequestID: apiErr.RequestID, Err: apiErr}
+	}
+	return err
+}
@@ -0,0 +1,276 @@
+package agentbay
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/browser"
+)
+
+// ExtensionsBasePath is the folder of the extension context in which extensions are stored
+const ExtensionsBasePath = "/tmp/extensions"
+
+// Extension represents a browser extension stored in an extension context.
+type Extension struct {
+	// ID is the unique identifier of the extension, e.g. "ext_0123abcd.zip".
+	ID string
+
+	// Name is the file name the extension was uploaded from.
+	Name string
+
+	// CreatedAt is the date and time when the extension was uploaded, if known.
+	CreatedAt string
+}
+
+// ExtensionOption selects the extensions of an extension context to load into the browser.
+type ExtensionOption struct {
+	// ContextID is the ID of the context in which the extensions are stored.
+	ContextID string
+
+	// ExtensionIDs are the IDs of the extensions to load.
+	ExtensionIDs []string
+}
+
+// NewExtensionOption creates an extension option and validates it.
+func NewExtensionOption(contextID string, extensionIDs []string) (*ExtensionOption, error) {
+	option := &ExtensionOption{ContextID: contextID, ExtensionIDs: extensionIDs}
+	if err := option.Validate(); err != nil {
+		return nil, err
+	}
+	return option, nil
+}
+
+// Validate checks that the option names a context and at least one extension.
+func (o *ExtensionOption) Validate() error {
+	if strings.TrimSpace(o.ContextID) == "" {
+		return fmt.Errorf("context ID cannot be empty")
+	}
+	if len(o.ExtensionIDs) == 0 {
+		return fmt.Errorf("extension IDs cannot be empty")
+	}
+	for _, id := range o.ExtensionIDs {
+		if strings.TrimSpace(id) == "" {
+			return fmt.Errorf("extension ID cannot be empty")
+		}
+	}
+	return nil
+}
+
+// ContextSync returns the context sync that downloads the selected extensions into the
+// browser's extension folder when a session is created.
+func (o *ExtensionOption) ContextSync() *ContextSync {
+	whiteLists := make([]*WhiteList, 0, len(o.ExtensionIDs))
+	for _, id := range o.ExtensionIDs {
+		whiteLists = append(whiteLists, &WhiteList{Path: id, ExcludePaths: []string{}})
+	}
+
+	policy := NewSyncPolicy()
+	policy.UploadPolicy.AutoUpload = false
+	policy.ExtractPolicy = &ExtractPolicy{Extract: true, DeleteSrcFile: true}
+	policy.BWList = &BWList{WhiteLists: whiteLists}
+
+	return &ContextSync{
+		ContextID: o.ContextID,
+		Path:      browser.DefaultExtensionPath,
+		Policy:    policy,
+	}
+}
+
+// ExtensionsService manages browser extensions stored in a context.
+// Extensions are uploaded as ZIP files and can then be loaded into browser sessions through
+// BrowserContext.
+type ExtensionsService struct {
+	AgentBay *AgentBay
+
+	// ContextID is the ID of the context in which the extensions are stored.
+	ContextID string
+
+	// ContextName is the name of the context in which the extensions are stored.
+	ContextName string
+
+	context    *Context
+	httpClient *http.Client
+}
+
+// NewExtensionsService creates a service for the extension context with the given name,
+// creating the context if it does not exist. If contextName is empty a name is generated.
+func NewExtensionsService(agentBay *AgentBay, contextName string) (*ExtensionsService, error) {
+	if strings.TrimSpace(contextName) == "" {
+		contextName = fmt.Sprintf("extensions-%d", time.Now().Unix())
+		agentBay.GetLogger().Info("Generated extension context name", "name", contextName)
+	}
+
+	result, err := agentBay.Context.Get(contextName, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create extension context %s: %w", contextName, err)
+	}
+	if !result.Success || result.Context == nil {
+		return nil, fmt.Errorf("failed to create extension context %s: %s", contextName, result.ErrorMessage)
+	}
+
+	return &ExtensionsService{
+		AgentBay:    agentBay,
+		ContextID:   result.Context.ID,
+		ContextName: contextName,
+		context:     result.Context,
+		httpClient:  http.DefaultClient,
+	}, nil
+}
+
+// List returns the extensions stored in the extension context.
+func (es *ExtensionsService) List() ([]*Extension, error) {
+	result, err := es.AgentBay.Context.ListFiles(es.ContextID, ExtensionsBasePath, 1, 100)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list extensions: %w", err)
+	}
+	if !result.Success {
+		return nil, fmt.Errorf("failed to list extensions: %s", result.ErrorMessage)
+	}
+
+	extensions := make([]*Extension, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		extensions = append(extensions, &Extension{
+			ID:        entry.FileName,
+			Name:      entry.FileName,
+			CreatedAt: entry.GmtCreate,
+		})
+	}
+	return extensions, nil
+}
+
+// Create uploads the extension ZIP file at localPath and returns the new extension.
+func (es *ExtensionsService) Create(localPath string) (*Extension, error) {
+	if err := validateExtensionFile(localPath); err != nil {
+		return nil, err
+	}
+
+	suffix := make([]byte, 16)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, fmt.Errorf("failed to generate extension ID: %w", err)
+	}
+	extensionID := "ext_" + hex.EncodeToString(suffix) + ".zip"
+	if err := es.upload(localPath, extensionID); err != nil {
+		return nil, err
+	}
+
+	es.AgentBay.GetLogger().Info("Extension uploaded", "context_id", es.ContextID, "extension_id", extensionID)
+	return &Extension{ID: extensionID, Name: filepath.Base(localPath)}, nil
+}
+
+// Update replaces the extension with the given ID by the ZIP file at localPath.
+func (es *ExtensionsService) Update(extensionID string, localPath string) (*Extension, error) {
+	if err := validateExtensionFile(localPath); err != nil {
+		return nil, err
+	}
+
+	extensions, err := es.List()
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, extension := range extensions {
+		if extension.ID == extensionID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("extension %s not found in context %s", extensionID, es.ContextName)
+	}
+
+	if err := es.upload(localPath, extensionID); err != nil {
+		return nil, err
+	}
+	return &Extension{ID: extensionID, Name: filepath.Base(localPath)}, nil
+}
+
+// Delete removes the extension with the given ID from the extension context.
+func (es *ExtensionsService) Delete(extensionID string) error {
+	result, err := es.AgentBay.Context.DeleteFile(es.ContextID, extensionPath(extensionID))
+	if err != nil {
+		return fmt.Errorf("failed to delete extension %s: %w", extensionID, err)
+	}
+	if !result.Success {
+		return fmt.Errorf("failed to delete extension %s: %s", extensionID, result.ErrorMessage)
+	}
+	return nil
+}
+
+// Cleanup deletes the extension context together with all extensions stored in it.
+func (es *ExtensionsService) Cleanup() error {
+	result, err := es.AgentBay.Context.Delete(es.context)
+	if err != nil {
+		return fmt.Errorf("failed to delete extension context %s: %w", es.ContextName, err)
+	}
+	if !result.Success {
+		return fmt.Errorf("failed to delete extension context %s: %s", es.ContextName, result.ErrorMessage)
+	}
+	es.AgentBay.GetLogger().Info("Extension context deleted", "context_id", es.ContextID, "name", es.ContextName)
+	return nil
+}
+
+// CreateExtensionOption returns an ExtensionOption selecting the given extensions of this
+// service's context, for use with BrowserContext.
+func (es *ExtensionsService) CreateExtensionOption(extensionIDs []string) (*ExtensionOption, error) {
+	return NewExtensionOption(es.ContextID, extensionIDs)
+}
+
+// upload puts the file at localPath into the extension context under extensionID
+func (es *ExtensionsService) upload(localPath string, extensionID string) error {
+	urlResult, err := es.AgentBay.Context.GetFileUploadUrl(es.ContextID, extensionPath(extensionID))
+	if err != nil {
+		return fmt.Errorf("failed to get upload URL for extension %s: %w", extensionID, err)
+	}
+	if !urlResult.Success || urlResult.Url == "" {
+		return fmt.Errorf("failed to get upload URL for extension %s: no URL returned", extensionID)
+	}
+
+	file, err := os.Open(localPath)
+	if err != nil {
+		return &FileError{Path: localPath, Op: "open extension", Err: err}
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return &FileError{Path: localPath, Op: "open extension", Err: err}
+	}
+
+	request, err := http.NewRequest(http.MethodPut, urlResult.Url, file)
+	if err != nil {
+		return fmt.Errorf("failed to upload extension %s: %w", extensionID, err)
+	}
+	request.ContentLength = info.Size()
+
+	response, err := es.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to upload extension %s: %w", extensionID, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("failed to upload extension %s: status %d", extensionID, response.StatusCode)
+	}
+	return nil
+}
+
+// validateExtensionFile checks that localPath is an existing ZIP file
+func validateExtensionFile(localPath string) error {
+	if _, err := os.Stat(localPath); err != nil {
+		return &FileError{Path: localPath, Op: "open extension", Err: err}
+	}
+	if ext := strings.ToLower(filepath.Ext(localPath)); ext != ".zip" {
+		return fmt.Errorf("unsupported extension format %q, only ZIP files are supported", ext)
+	}
+	return nil
+}
+
+func extensionPath(extensionID string) string {
+	return ExtensionsBasePath + "/" + extensionID
+}
@@ -0,0 +1,273 @@
+package filesystem
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+)
+
+// ArchiveFormat is the format of an archive created by Pack or WriteArchive
+type ArchiveFormat string
+
+const (
+	// ArchiveTarGz is a gzip compressed tar archive. It requires tar in the session.
+	ArchiveTarGz ArchiveFormat = "tar.gz"
+	// ArchiveZip is a zip archive. It requires zip and unzip, or python3, in the session.
+	ArchiveZip ArchiveFormat = "zip"
+)
+
+// ExtractPolicy defines how archives are extracted. It is used by context synchronization and
+// by Unpack and UploadArchive.
+type ExtractPolicy struct {
+	// Extract enables file extraction
+	Extract bool `json:"extract"`
+	// DeleteSrcFile enables deletion of source file after extraction
+	DeleteSrcFile bool `json:"deleteSrcFile"`
+	// ExtractToCurrentFolder enables extraction to current folder, the folder containing the
+	// archive. Otherwise the archive is extracted into a folder next to it that is named after
+	// the archive without its extension.
+	ExtractToCurrentFolder bool `json:"extractToCurrentFolder"`
+}
+
+// ArchiveUploadResult summarises an UploadArchive call
+type ArchiveUploadResult struct {
+	models.ApiResponse
+	// ArchivePath is the path of the uploaded archive in the session
+	ArchivePath string
+	// Destination is the directory the archive was extracted into, or empty if it was not
+	// extracted
+	Destination string
+	// Files is the number of files in the archive
+	Files int
+	// Bytes is the size of the archive
+	Bytes int64
+}
+
+// Pack creates an archive at archivePath in the session that contains the files and
+// directories at paths, each stored under its base name. An existing archive is replaced. If
+// format is empty, it is derived from the extension of archivePath.
+func (fs *FileSystem) Pack(paths []string, archivePath string, format ArchiveFormat) (*FileWriteResult, error) {
+	return fs.PackWithContext(context.Background(), paths, archivePath, format)
+}
+
+// PackWithContext creates an archive at archivePath like Pack, honouring the cancellation and
+// deadline of ctx.
+func (fs *FileSystem) PackWithContext(ctx context.Context, paths []string, archivePath string, format ArchiveFormat) (*FileWriteResult, error) {
+	const op = "pack"
+	if len(paths) == 0 {
+		return nil, fileError(op, archivePath, fmt.Errorf("no paths to pack"))
+	}
+	if err := validatePaths(op, append([]string{archivePath}, paths...)...); err != nil {
+		return nil, err
+	}
+	format, err := archiveFormat(op, archivePath, format)
+	if err != nil {
+		return nil, err
+	}
+
+	// The archive path is made absolute, as zip is run in the directory of each path
+	script := "a=" + shellQuote(archivePath) + `; case "$a" in /*) ;; *) a="$PWD/$a" ;; esac; rm -f -- "$a"`
+	switch format {
+	case ArchiveTarGz:
+		script += ` && tar -czf "$a"`
+		for _, p := range paths {
+			dir, base := path.Split(cleanRemotePath(p))
+			if dir == "" {
+				dir = "."
+			}
+			script += ` -C "$(cd -- ` + shellQuote(dir) + ` && pwd)" ` + shellQuote("./"+base)
+		}
+	case ArchiveZip:
+		var zipScript, pythonScript string
+		for _, p := range paths {
+			dir, base := path.Split(cleanRemotePath(p))
+			if dir == "" {
+				dir = "."
+			}
+			zipScript += ` && (cd -- ` + shellQuote(dir) + ` && zip -qry "$a" ` + shellQuote("./"+base) + `)`
+			pythonScript += " " + shellQuote(p)
+		}
+		script += " && if command -v zip >/dev/null 2>&1; then true" + zipScript +
+			`; else python3 -m zipfile -c "$a"` + pythonScript + "; fi"
+	}
+	return fs.runFileCommand(ctx, op, archivePath, script)
+}
+
+// Unpack extracts the archive at archivePath in the session into the directory dest, which is
+// created if needed. If dest is empty, the destination follows policy.ExtractToCurrentFolder.
+// If policy.DeleteSrcFile is set, the archive is removed after it was extracted. policy may be
+// nil, which keeps the archive; policy.Extract is ignored.
+func (fs *FileSystem) Unpack(archivePath, dest string, policy *ExtractPolicy) (*FileWriteResult, error) {
+	return fs.UnpackWithContext(context.Background(), archivePath, dest, policy)
+}
+
+// UnpackWithContext extracts the archive at archivePath like Unpack, honouring the cancellation
+// and deadline of ctx.
+func (fs *FileSystem) UnpackWithContext(ctx context.Context, archivePath, dest string, policy *ExtractPolicy) (*FileWriteResult, error) {
+	const op = "unpack"
+	if err := validatePaths(op, archivePath); err != nil {
+		return nil, err
+	}
+	format, err := archiveFormat(op, archivePath, "")
+	if err != nil {
+		return nil, err
+	}
+	if dest == "" {
+		dest = extractDestination(archivePath, policy)
+	}
+
+	script := "mkdir -p -- " + shellQuote(dest) + " && "
+	switch format {
+	case ArchiveTarGz:
+		script += "tar -xzf " + shellQuote(archivePath) + " -C " + shellQuote(dest)
+	case ArchiveZip:
+		script += "if command -v unzip >/dev/null 2>&1; then unzip -oq " + shellQuote(archivePath) + " -d " + shellQuote(dest) +
+			"; else python3 -m zipfile -e " + shellQuote(archivePath) + " " + shellQuote(dest) + "; fi"
+	}
+	if policy != nil && policy.DeleteSrcFile {
+		script += " && rm -f -- " + shellQuote(archivePath)
+	}
+	return fs.runFileCommand(ctx, op, archivePath, script)
+}
+
+// UploadArchive packs the regular files below localDir into an in-memory archive, uploads it to
+// archivePath in a single write and, if policy.Extract is set, extracts it in the session
+// according to policy like Unpack. The format is derived from the extension of archivePath.
+// policy may be nil, which only uploads the archive.
+func (fs *FileSystem) UploadArchive(ctx context.Context, localDir, archivePath string, policy *ExtractPolicy) (*ArchiveUploadResult, error) {
+	const op = "upload archive"
+	if err := validatePaths(op, localDir, archivePath); err != nil {
+		return nil, err
+	}
+	format, err := archiveFormat(op, archivePath, "")
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := listLocalDir(localDir, nil)
+	if err != nil {
+		return nil, fileError(op, localDir, err)
+	}
+	var buf bytes.Buffer
+	if err := writeArchive(&buf, localDir, entries, format); err != nil {
+		return nil, fileError(op, localDir, err)
+	}
+
+	writeResult, err := fs.WriteFileBytesWithContext(ctx, archivePath, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	result := &ArchiveUploadResult{
+		ApiResponse: writeResult.ApiResponse,
+		ArchivePath: archivePath,
+		Files:       len(entries),
+		Bytes:       int64(buf.Len()),
+	}
+	if policy == nil || !policy.Extract {
+		return result, nil
+	}
+
+	unpackResult, err := fs.UnpackWithContext(ctx, archivePath, "", policy)
+	if err != nil {
+		return nil, err
+	}
+	result.RequestID = unpackResult.RequestID
+	result.Destination = extractDestination(archivePath, policy)
+	return result, nil
+}
+
+// WriteArchive writes an archive of the regular files below localDir to w. Paths in the
+// archive are relative to localDir.
+func WriteArchive(w io.Writer, localDir string, format ArchiveFormat) error {
+	entries, err := listLocalDir(localDir, nil)
+	if err != nil {
+		return err
+	}
+	return writeArchive(w, localDir, entries, format)
+}
+
+// extractDestination returns the directory an archive is extracted into when no destination
+// is given
+func extractDestination(archivePath string, policy *ExtractPolicy) string {
+	dir := path.Dir(archivePath)
+	if policy != nil && policy.ExtractToCurrentFolder {
+		return dir
+	}
+	base := path.Base(archivePath)
+	for _, ext := range []string{".tar.gz", ".tgz", ".zip"} {
+		if strings.HasSuffix(base, ext) && len(base) > len(ext) {
+			base = strings.TrimSuffix(base, ext)
+			break
+		}
+	}
+	return path.Join(dir, base)
+}
+
+// archiveFormat returns format, or the format matching the extension of archivePath if format
+// is empty
+func archiveFormat(op, archivePath string, format ArchiveFormat) (ArchiveFormat, error) {
+	switch {
+	case format == ArchiveTarGz || format == ArchiveZip:
+		return format, nil
+	case format != "":
+		return "", fileError(op, archivePath, fmt.Errorf("unsupported archive format %q", format))
+	case strings.HasSuffix(archivePath, ".tar.gz"), strings.HasSuffix(archivePath, ".tgz"):
+		return ArchiveTarGz, nil
+	case strings.HasSuffix(archivePath, ".zip"):
+		return ArchiveZip, nil
+	}
+	return "", fileError(op, archivePath, fmt.Errorf("cannot derive the archive format from the file name"))
+}
+
+// writeArchive writes the local files entries below localDir to w in the given format
+func writeArchive(w io.Writer, localDir string, entries []*syncEntry, format ArchiveFormat) error {
+	switch format {
+	case ArchiveTarGz:
+		return writeTarGz(w, localDir, entries)
+	case ArchiveZip:
+		return writeZip(w, localDir, entries)
+	}
+	return fmt.Errorf("unsupported archive format %q", format)
+}
+
+// writeZip writes a zip archive of the local files entries below localDir to w
+func writeZip(w io.Writer, localDir string, entries []*syncEntry) error {
+	zw := zip.NewWriter(w)
+	for _, entry := range entries {
+		name := filepath.Join(localDir, filepath.FromSlash(entry.rel))
+		info, err := os.Stat(name)
+		if err != nil {
+			return err
+		}
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = entry.rel
+		header.Method = zip.Deflate
+		header.Modified = info.ModTime().Truncate(time.Second)
+		writer, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(writer, file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
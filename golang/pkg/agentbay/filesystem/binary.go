@@ -0,0 +1,184 @@
+package filesystem
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+)
+
+// BinaryChunkSize is the number of bytes ReadFileBytes transfers per call. Its base64 encoding
+// fits into ChunkSize.
+const BinaryChunkSize = ChunkSize / 4 * 3
+
+// shellTimeoutMs is the timeout of the shell commands used for binary transfers
+const shellTimeoutMs = 60000
+
+// ErrChecksumMismatch is returned when the SHA-256 checksum of a transferred file differs
+// between the session and the client
+var ErrChecksumMismatch = errors.New("checksum mismatch after transfer")
+
+// FileReadBytesResult wraps the raw contents of a file read by ReadFileBytes and RequestID
+type FileReadBytesResult struct {
+	models.ApiResponse
+	Content []byte
+	// SHA256 is the hex encoded checksum of Content, verified against the file in the session
+	SHA256 string
+}
+
+// ReadFileBytes reads the raw contents of a file. Unlike ReadFile it is safe for binary files:
+// the contents are transferred base64 encoded through the shell tool and verified against their
+// SHA-256 checksum. Empty files yield empty content.
+func (fs *FileSystem) ReadFileBytes(path string) (*FileReadBytesResult, error) {
+	return fs.ReadFileBytesWithContext(context.Background(), path)
+}
+
+// ReadFileBytesWithContext reads the raw contents of a file like ReadFileBytes, stopping between
+// chunks and aborting in-flight requests when ctx is cancelled or its deadline expires.
+func (fs *FileSystem) ReadFileBytesWithContext(ctx context.Context, path string) (*FileReadBytesResult, error) {
+	size, checksum, _, err := fs.fileChecksum(ctx, "read file", path, "")
+	if err != nil {
+		return nil, err
+	}
+
+	log := fs.logger()
+	log.Debug("ReadFileBytes: starting chunked read", "path", path, "size", size, "chunk_size", BinaryChunkSize)
+
+	content := make([]byte, 0, size)
+	var lastRequestID string
+	for offset := int64(0); offset < size; offset += BinaryChunkSize {
+		script := "tail -c +" + strconv.FormatInt(offset+1, 10) + " " + shellQuote(path) +
+			" | head -c " + strconv.Itoa(BinaryChunkSize) + " | base64"
+		result, err := fs.shell(ctx, "read file", path, script)
+		if err != nil {
+			return nil, fmt.Errorf("error reading chunk at offset %d: %w", offset, err)
+		}
+		chunk, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(result.Data), ""))
+		if err != nil {
+			return nil, fileError("read file", path, fmt.Errorf("failed to decode chunk at offset %d: %w", offset, err))
+		}
+		content = append(content, chunk...)
+		lastRequestID = result.RequestID
+	}
+
+	sum := sha256.Sum256(content)
+	if hex.EncodeToString(sum[:]) != checksum {
+		return nil, fileError("read file", path, ErrChecksumMismatch)
+	}
+
+	log.Debug("ReadFileBytes: read complete", "path", path, "size", len(content))
+
+	return &FileReadBytesResult{
+		ApiResponse: models.ApiResponse{
+			RequestID: lastRequestID,
+		},
+		Content: content,
+		SHA256:  checksum,
+	}, nil
+}
+
+// WriteFileBytes writes raw data to a file, replacing its contents. Unlike WriteFile it is safe
+// for binary data: the data is uploaded base64 encoded to a temporary file next to path, decoded
+// in the session and verified against its SHA-256 checksum. Empty data creates an empty file.
+func (fs *FileSystem) WriteFileBytes(path string, data []byte) (*FileWriteResult, error) {
+	return fs.WriteFileBytesWithContext(context.Background(), path, data)
+}
+
+// WriteFileBytesWithContext writes raw data to a file like WriteFileBytes, stopping between
+// chunks and aborting in-flight requests when ctx is cancelled or its deadline expires.
+func (fs *FileSystem) WriteFileBytesWithContext(ctx context.Context, path string, data []byte) (*FileWriteResult, error) {
+	log := fs.logger()
+	log.Debug("WriteFileBytes: starting write", "path", path, "size", len(data))
+
+	// The encoded data is plain ASCII, so it can be written in chunks with write_file without
+	// splitting multi-byte sequences.
+	prepare := ": > " + shellQuote(path)
+	if len(data) > 0 {
+		suffix := make([]byte, 8)
+		if _, err := rand.Read(suffix); err != nil {
+			return nil, fileError("write file", path, fmt.Errorf("failed to generate temporary file name: %w", err))
+		}
+		tempPath := path + ".agentbay-" + hex.EncodeToString(suffix) + ".b64"
+		if _, err := fs.WriteFileWithContext(ctx, tempPath, base64.StdEncoding.EncodeToString(data), "overwrite"); err != nil {
+			_, _ = fs.shell(context.Background(), "write file", path, "rm -f "+shellQuote(tempPath))
+			return nil, err
+		}
+		prepare = "base64 -d < " + shellQuote(tempPath) + " > " + shellQuote(path) + "; rm -f " + shellQuote(tempPath)
+	}
+
+	size, checksum, requestID, err := fs.fileChecksum(ctx, "write file", path, prepare)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if size != int64(len(data)) || checksum != hex.EncodeToString(sum[:]) {
+		return nil, fileError("write file", path, ErrChecksumMismatch)
+	}
+
+	log.Debug("WriteFileBytes: write complete", "path", path, "size", len(data))
+
+	return &FileWriteResult{
+		ApiResponse: models.ApiResponse{
+			RequestID: requestID,
+		},
+		Success: true,
+	}, nil
+}
+
+// fileChecksum runs prepare, if any, and returns the size and hex encoded SHA-256 checksum of
+// the regular file at path together with the ID of the request
+func (fs *FileSystem) fileChecksum(ctx context.Context, op, path, prepare string) (int64, string, string, error) {
+	quoted := shellQuote(path)
+	script := "if [ -f " + quoted + " ]; then wc -c < " + quoted + "; sha256sum < " + quoted + "; else echo missing; fi"
+	if prepare != "" {
+		script = prepare + "; " + script
+	}
+	result, err := fs.shell(ctx, op, path, script)
+	if err != nil {
+		return 0, "", "", err
+	}
+
+	fields := strings.Fields(result.Data)
+	if len(fields) == 1 && fields[0] == "missing" {
+		return 0, "", "", fileError(op, path, os.ErrNotExist)
+	}
+	if len(fields) < 2 {
+		return 0, "", "", fileError(op, path, fmt.Errorf("unexpected checksum output %q", result.Data))
+	}
+	size, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, "", "", fileError(op, path, fmt.Errorf("invalid file size %q", fields[0]))
+	}
+	return size, strings.ToLower(fields[1]), result.RequestID, nil
+}
+
+// shell runs script with the shell tool of the session
+func (fs *FileSystem) shell(ctx context.Context, op, path, script string) (*models.McpToolResult, error) {
+	args := map[string]interface{}{
+		"command":    script,
+		"timeout_ms": shellTimeoutMs,
+	}
+
+	result, err := models.CallMcpToolWithContext(ctx, fs.Session, "shell", args)
+	if err != nil {
+		return nil, fileError(op, path, err)
+	}
+
+	if !result.Success {
+		return nil, fileError(op, path, models.NewToolError("shell", result))
+	}
+	return result, nil
+}
+
+// shellQuote quotes s for use as a single word in a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
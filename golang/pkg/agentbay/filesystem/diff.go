@@ -0,0 +1,325 @@
+package filesystem
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines around each hunk of a unified diff
+const diffContext = 3
+
+const noNewlineMarker = `\ No newline at end of file`
+
+// diffOp is a line of a diff. kind is ' ' for an unchanged line, '-' for a removed line and
+// '+' for an added line. line includes its line break, unless it is the last line of a file
+// that does not end with one.
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// diffHunk is a hunk of a unified diff
+type diffHunk struct {
+	oldStart, oldLines int
+	newStart, newLines int
+	ops                []diffOp
+}
+
+func (h *diffHunk) header() string {
+	return "@@ -" + hunkRange(h.oldStart, h.oldLines) + " +" + hunkRange(h.newStart, h.newLines) + " @@"
+}
+
+func hunkRange(start, lines int) string {
+	if lines == 1 {
+		return strconv.Itoa(start)
+	}
+	return strconv.Itoa(start) + "," + strconv.Itoa(lines)
+}
+
+// UnifiedDiff returns the unified diff, with three lines of context, that turns oldContent into
+// newContent. path is used in the file headers. The result is empty if the contents are equal.
+func UnifiedDiff(path, oldContent, newContent string) string {
+	hunks := diffHunks(diffLines(splitLines(oldContent), splitLines(newContent)))
+	if len(hunks) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("--- " + path + "\n+++ " + path + "\n")
+	for _, hunk := range hunks {
+		writeHunk(&b, hunk)
+	}
+	return b.String()
+}
+
+func writeHunk(b *strings.Builder, hunk *diffHunk) {
+	b.WriteString(hunk.header() + "\n")
+	for _, op := range hunk.ops {
+		b.WriteByte(op.kind)
+		b.WriteString(op.line)
+		if !strings.HasSuffix(op.line, "\n") {
+			b.WriteString("\n" + noNewlineMarker + "\n")
+		}
+	}
+}
+
+// splitLines splits s after each line break
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns a shortest edit script turning a into b, computed with Myers' algorithm
+// after stripping the common prefix and suffix
+func diffLines(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	ops = append(ops, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops
+}
+
+func myers(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	if n+m == 0 {
+		return nil
+	}
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	// trace[d] holds the furthest reaching x for each diagonal k before step d
+	var trace [][]int
+
+search:
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	// Walk the trace backwards, collecting the operations in reverse
+	var reversed []diffOp
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			reversed = append(reversed, diffOp{' ', a[x]})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			reversed = append(reversed, diffOp{'+', b[prevY]})
+		} else {
+			reversed = append(reversed, diffOp{'-', a[prevX]})
+		}
+		x, y = prevX, prevY
+	}
+
+	ops := make([]diffOp, len(reversed))
+	for i, op := range reversed {
+		ops[len(ops)-1-i] = op
+	}
+	return ops
+}
+
+// diffHunks groups an edit script into hunks with diffContext lines of context, merging hunks
+// whose context would overlap
+func diffHunks(ops []diffOp) []*diffHunk {
+	var hunks []*diffHunk
+	var current *diffHunk
+	oldLine, newLine := 0, 0
+	lastChange := -1
+	for i, op := range ops {
+		if op.kind != ' ' {
+			if current == nil || i-lastChange-1 > 2*diffContext {
+				start := max(i-diffContext, lastChange+1, 0)
+				if current != nil {
+					closeHunk(current, ops, lastChange)
+				}
+				current = &diffHunk{oldStart: oldLine, newStart: newLine}
+				hunks = append(hunks, current)
+				for _, unchanged := range ops[start:i] {
+					current.ops = append(current.ops, unchanged)
+					current.oldStart--
+					current.newStart--
+					current.oldLines++
+					current.newLines++
+				}
+			} else {
+				for _, unchanged := range ops[lastChange+1 : i] {
+					current.ops = append(current.ops, unchanged)
+					current.oldLines++
+					current.newLines++
+				}
+			}
+			current.ops = append(current.ops, op)
+			if op.kind == '-' {
+				current.oldLines++
+			} else {
+				current.newLines++
+			}
+			lastChange = i
+		}
+		if op.kind != '+' {
+			oldLine++
+		}
+		if op.kind != '-' {
+			newLine++
+		}
+	}
+	if current != nil {
+		closeHunk(current, ops, lastChange)
+	}
+
+	// Ranges start at one, or name the line before an empty range
+	for _, hunk := range hunks {
+		if hunk.oldLines > 0 {
+			hunk.oldStart++
+		}
+		if hunk.newLines > 0 {
+			hunk.newStart++
+		}
+	}
+	return hunks
+}
+
+// closeHunk appends the trailing context after the last change of hunk
+func closeHunk(hunk *diffHunk, ops []diffOp, lastChange int) {
+	end := min(lastChange+1+diffContext, len(ops))
+	for _, unchanged := range ops[lastChange+1 : end] {
+		hunk.ops = append(hunk.ops, unchanged)
+		hunk.oldLines++
+		hunk.newLines++
+	}
+}
+
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// parseUnifiedDiff parses the hunks of a unified diff of a single file. File headers and
+// other lines outside hunks are ignored.
+func parseUnifiedDiff(patch string) ([]*diffHunk, error) {
+	var hunks []*diffHunk
+	lines := splitLines(patch)
+	files := 0
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r\n")
+		if strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ ") {
+			if files++; files > 1 {
+				return nil, fmt.Errorf("patch changes more than one file")
+			}
+			i++
+			continue
+		}
+		match := hunkHeaderPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		hunk := &diffHunk{
+			oldStart: atoi(match[1]), oldLines: atoiDefault(match[2], 1),
+			newStart: atoi(match[3]), newLines: atoiDefault(match[4], 1),
+		}
+		oldLeft, newLeft := hunk.oldLines, hunk.newLines
+		for (oldLeft > 0 || newLeft > 0) && i+1 < len(lines) {
+			i++
+			body := lines[i]
+			if body == "\n" || body == "\r\n" {
+				// Some tools strip the space of empty context lines
+				body = " " + body
+			}
+			kind := body[0]
+			switch kind {
+			case ' ':
+				oldLeft--
+				newLeft--
+			case '-':
+				oldLeft--
+			case '+':
+				newLeft--
+			case '\\':
+				stripNewline(hunk)
+				continue
+			default:
+				return nil, fmt.Errorf("invalid line in hunk %s: %q", hunk.header(), strings.TrimRight(body, "\n"))
+			}
+			hunk.ops = append(hunk.ops, diffOp{kind, body[1:]})
+		}
+		if oldLeft != 0 || newLeft != 0 {
+			return nil, fmt.Errorf("hunk %s is truncated", hunk.header())
+		}
+		if i+1 < len(lines) && strings.HasPrefix(lines[i+1], "\\") {
+			i++
+			stripNewline(hunk)
+		}
+		hunks = append(hunks, hunk)
+	}
+	if len(hunks) == 0 {
+		return nil, fmt.Errorf("patch contains no hunks")
+	}
+	return hunks, nil
+}
+
+// stripNewline removes the line break of the last line of hunk, after a "\ No newline at end
+// of file" marker
+func stripNewline(hunk *diffHunk) {
+	if len(hunk.ops) > 0 {
+		last := &hunk.ops[len(hunk.ops)-1]
+		last.line = strings.TrimSuffix(last.line, "\n")
+	}
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func atoiDefault(s string, def int) int {
+	if s == "" {
+		return def
+	}
+	return atoi(s)
+}
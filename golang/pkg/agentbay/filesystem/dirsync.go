@@ -0,0 +1,653 @@
+package filesystem
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/logger"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+)
+
+// CompareMode selects how UploadDir and DownloadDir decide whether a file is unchanged
+type CompareMode int
+
+const (
+	// CompareSizeModTime skips files whose size and modification time (in whole seconds) match
+	CompareSizeModTime CompareMode = iota
+	// CompareHash skips files whose SHA-256 checksums match
+	CompareHash
+	// CompareNone transfers every file
+	CompareNone
+)
+
+// touchBatchSize is the number of files whose modification time is set per shell call
+const touchBatchSize = 100
+
+// DirSyncOptions configures UploadDir and DownloadDir
+type DirSyncOptions struct {
+	// Include lists glob patterns, in path.Match syntax, of the files to transfer. A pattern
+	// matches a slash separated path relative to the synced directory, its base name or one of
+	// its parent directories. If empty, all files are included.
+	Include []string
+	// Exclude lists glob patterns of files and directories to leave out. Exclude takes
+	// precedence over Include.
+	Exclude []string
+	// Compare selects how unchanged files are detected. Defaults to CompareSizeModTime.
+	Compare CompareMode
+	// Concurrency is the number of files transferred in parallel. Defaults to
+	// DefaultTransferConcurrency. It is ignored when Archive is set.
+	Concurrency int
+	// Archive packs the files into a single tar.gz archive that is transferred in one go and
+	// extracted on the other side, which is much faster for many small files. It requires tar
+	// in the session.
+	Archive bool
+}
+
+func (o *DirSyncOptions) concurrency() int {
+	if o == nil || o.Concurrency <= 0 {
+		return DefaultTransferConcurrency
+	}
+	return o.Concurrency
+}
+
+func (o *DirSyncOptions) compare() CompareMode {
+	if o == nil {
+		return CompareSizeModTime
+	}
+	return o.Compare
+}
+
+func (o *DirSyncOptions) archive() bool {
+	return o != nil && o.Archive
+}
+
+// matches reports whether the relative path rel passes the Include and Exclude filters
+func (o *DirSyncOptions) matches(rel string) bool {
+	if o == nil {
+		return true
+	}
+	if len(o.Exclude) > 0 && matchAny(o.Exclude, rel) {
+		return false
+	}
+	return len(o.Include) == 0 || matchAny(o.Include, rel)
+}
+
+// excludesDir reports whether the directory with the relative path rel is excluded entirely
+func (o *DirSyncOptions) excludesDir(rel string) bool {
+	return o != nil && len(o.Exclude) > 0 && matchAny(o.Exclude, rel)
+}
+
+// matchAny reports whether any pattern matches rel, its base name or one of its parent
+// directories
+func matchAny(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, path.Base(rel)); ok {
+			return true
+		}
+		for dir := rel; dir != "." && dir != "/"; dir = path.Dir(dir) {
+			if ok, _ := path.Match(pattern, dir); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DirSyncResult summarises an UploadDir or DownloadDir call
+type DirSyncResult struct {
+	models.ApiResponse
+	// Transferred lists the relative paths of the files that were transferred
+	Transferred []string
+	// Skipped lists the relative paths of the files that were unchanged
+	Skipped []string
+	// Failed maps the relative paths of the files that could not be transferred to their error
+	Failed map[string]error
+	// Bytes is the total size of the transferred files
+	Bytes int64
+	// Duration is the time the call took
+	Duration time.Duration
+}
+
+// syncEntry describes a regular file on one side of a directory sync
+type syncEntry struct {
+	rel     string
+	size    int64
+	modTime int64 // Unix seconds
+	sha256  string
+}
+
+// UploadDir copies the regular files below localDir into remoteDir in the session, creating
+// directories as needed and setting the modification times of the uploaded files to those of
+// the local files. Files that are unchanged according to options.Compare are skipped. Files
+// that fail to upload are reported in DirSyncResult.Failed and an error is returned together
+// with the result. options may be nil.
+func (fs *FileSystem) UploadDir(ctx context.Context, localDir, remoteDir string, options *DirSyncOptions) (*DirSyncResult, error) {
+	start := time.Now()
+	log := fs.logger()
+
+	local, err := listLocalDir(localDir, options)
+	if err != nil {
+		return nil, fileError("upload directory", localDir, err)
+	}
+	remote, requestID, err := fs.listRemoteDir(ctx, "upload directory", remoteDir, options.compare() == CompareHash)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	result := &DirSyncResult{ApiResponse: models.ApiResponse{RequestID: requestID}, Failed: map[string]error{}}
+	var pending []*syncEntry
+	for _, entry := range local {
+		if unchanged(entry, remote[entry.rel], options.compare()) {
+			result.Skipped = append(result.Skipped, entry.rel)
+		} else {
+			pending = append(pending, entry)
+		}
+	}
+	log.Debug("UploadDir: starting upload", "local", localDir, "remote", remoteDir,
+		"files", len(local), "pending", len(pending), "archive", options.archive())
+
+	if len(pending) > 0 {
+		if options.archive() {
+			err = fs.uploadArchive(ctx, localDir, remoteDir, pending, result)
+		} else {
+			err = fs.uploadFiles(ctx, localDir, remoteDir, pending, options, result)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return finishSync(result, "upload directory", localDir, start)
+}
+
+// DownloadDir copies the regular files below remoteDir in the session into localDir,
+// creating directories as needed and setting the modification times of the downloaded files to
+// those in the session. Files that are unchanged according to options.Compare are skipped.
+// Files that fail to download are reported in DirSyncResult.Failed and an error is returned
+// together with the result. options may be nil.
+func (fs *FileSystem) DownloadDir(ctx context.Context, remoteDir, localDir string, options *DirSyncOptions) (*DirSyncResult, error) {
+	start := time.Now()
+	log := fs.logger()
+
+	remote, requestID, err := fs.listRemoteDir(ctx, "download directory", remoteDir, options.compare() == CompareHash)
+	if err != nil {
+		return nil, err
+	}
+	local, err := listLocalDir(localDir, options)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fileError("download directory", localDir, err)
+	}
+	localByPath := make(map[string]*syncEntry, len(local))
+	for _, entry := range local {
+		localByPath[entry.rel] = entry
+	}
+
+	result := &DirSyncResult{ApiResponse: models.ApiResponse{RequestID: requestID}, Failed: map[string]error{}}
+	var pending []*syncEntry
+	for _, entry := range sortedEntries(remote) {
+		if !options.matches(entry.rel) {
+			continue
+		}
+		if unchanged(entry, localByPath[entry.rel], options.compare()) {
+			result.Skipped = append(result.Skipped, entry.rel)
+		} else {
+			pending = append(pending, entry)
+		}
+	}
+	log.Debug("DownloadDir: starting download", "remote", remoteDir, "local", localDir,
+		"files", len(remote), "pending", len(pending), "archive", options.archive())
+
+	if len(pending) > 0 {
+		if options.archive() {
+			err = fs.downloadArchive(ctx, remoteDir, localDir, pending, result)
+		} else {
+			err = fs.downloadFiles(ctx, remoteDir, localDir, pending, options, result)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return finishSync(result, "download directory", remoteDir, start)
+}
+
+// finishSync sorts the lists of result and returns an error if any file failed
+func finishSync(result *DirSyncResult, op, dir string, start time.Time) (*DirSyncResult, error) {
+	sort.Strings(result.Transferred)
+	sort.Strings(result.Skipped)
+	result.Duration = time.Since(start)
+	if len(result.Failed) > 0 {
+		total := len(result.Failed) + len(result.Transferred)
+		return result, fileError(op, dir, fmt.Errorf("%d of %d files failed", len(result.Failed), total))
+	}
+	return result, nil
+}
+
+// unchanged reports whether the file described by source does not need to be transferred over
+// target, which is nil if the file does not exist
+func unchanged(source, target *syncEntry, mode CompareMode) bool {
+	if target == nil || source.size != target.size {
+		return false
+	}
+	switch mode {
+	case CompareSizeModTime:
+		return source.modTime == target.modTime
+	case CompareHash:
+		return source.sha256 == target.sha256
+	}
+	return false
+}
+
+// uploadFiles uploads the pending files one by one with WriteFileBytes
+func (fs *FileSystem) uploadFiles(ctx context.Context, localDir, remoteDir string, pending []*syncEntry,
+	options *DirSyncOptions, result *DirSyncResult) error {
+	dirs := map[string]bool{remoteDir: true}
+	for _, entry := range pending {
+		dirs[path.Join(remoteDir, path.Dir(entry.rel))] = true
+	}
+	quoted := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		quoted = append(quoted, shellQuote(dir))
+	}
+	sort.Strings(quoted)
+	if _, err := fs.shell(ctx, "upload directory", remoteDir, "mkdir -p "+strings.Join(quoted, " ")); err != nil {
+		return err
+	}
+
+	uploaded := fs.syncFiles(ctx, pending, options, result, func(entry *syncEntry) (string, error) {
+		data, err := os.ReadFile(filepath.Join(localDir, filepath.FromSlash(entry.rel)))
+		if err != nil {
+			return "", err
+		}
+		writeResult, err := fs.WriteFileBytesWithContext(ctx, path.Join(remoteDir, entry.rel), data)
+		if err != nil {
+			return "", err
+		}
+		return writeResult.RequestID, nil
+	})
+
+	// Copy the modification times so that unchanged files are skipped next time
+	for start := 0; start < len(uploaded); start += touchBatchSize {
+		end := start + touchBatchSize
+		if end > len(uploaded) {
+			end = len(uploaded)
+		}
+		var script strings.Builder
+		for _, entry := range uploaded[start:end] {
+			script.WriteString("touch -m -d @" + strconv.FormatInt(entry.modTime, 10) + " " +
+				shellQuote(path.Join(remoteDir, entry.rel)) + "; ")
+		}
+		if _, err := fs.shell(ctx, "upload directory", remoteDir, script.String()); err != nil {
+			fs.logger().Warn("UploadDir: failed to set modification times", "remote", remoteDir, logger.KeyError, err)
+		}
+	}
+	return nil
+}
+
+// downloadFiles downloads the pending files one by one with ReadFileBytes
+func (fs *FileSystem) downloadFiles(ctx context.Context, remoteDir, localDir string, pending []*syncEntry,
+	options *DirSyncOptions, result *DirSyncResult) error {
+	fs.syncFiles(ctx, pending, options, result, func(entry *syncEntry) (string, error) {
+		readResult, err := fs.ReadFileBytesWithContext(ctx, path.Join(remoteDir, entry.rel))
+		if err != nil {
+			return "", err
+		}
+		target := filepath.Join(localDir, filepath.FromSlash(entry.rel))
+		if err := writeLocalFile(target, readResult.Content, entry.modTime); err != nil {
+			return "", err
+		}
+		return readResult.RequestID, nil
+	})
+	return nil
+}
+
+// syncFiles calls transfer for every pending file using up to Concurrency goroutines, records
+// the outcome in result and returns the entries that were transferred
+func (fs *FileSystem) syncFiles(ctx context.Context, pending []*syncEntry, options *DirSyncOptions,
+	result *DirSyncResult, transfer func(entry *syncEntry) (string, error)) []*syncEntry {
+	queue := make(chan *syncEntry, len(pending))
+	for _, entry := range pending {
+		queue <- entry
+	}
+	close(queue)
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		done []*syncEntry
+	)
+	for worker := 0; worker < options.concurrency(); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for entry := range queue {
+				var requestID string
+				err := ctx.Err()
+				if err == nil {
+					requestID, err = transfer(entry)
+				}
+
+				mu.Lock()
+				if err != nil {
+					result.Failed[entry.rel] = err
+				} else {
+					result.Transferred = append(result.Transferred, entry.rel)
+					result.Bytes += entry.size
+					result.RequestID = requestID
+					done = append(done, entry)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return done
+}
+
+// uploadArchive packs the pending files into a tar.gz archive, uploads it and extracts it in
+// the session
+func (fs *FileSystem) uploadArchive(ctx context.Context, localDir, remoteDir string, pending []*syncEntry, result *DirSyncResult) error {
+	var buf bytes.Buffer
+	if err := writeTarGz(&buf, localDir, pending); err != nil {
+		return fileError("upload directory", localDir, err)
+	}
+
+	archivePath, err := tempPath(remoteDir, ".tar.gz")
+	if err != nil {
+		return fileError("upload directory", remoteDir, err)
+	}
+	if _, err := fs.shell(ctx, "upload directory", remoteDir, "mkdir -p "+shellQuote(remoteDir)); err != nil {
+		return err
+	}
+	if _, err := fs.WriteFileBytesWithContext(ctx, archivePath, buf.Bytes()); err != nil {
+		_, _ = fs.shell(context.Background(), "upload directory", remoteDir, "rm -f "+shellQuote(archivePath))
+		return err
+	}
+	script := "tar -xzf " + shellQuote(archivePath) + " -C " + shellQuote(remoteDir) +
+		" && echo extracted; rm -f " + shellQuote(archivePath)
+	extractResult, err := fs.shell(ctx, "upload directory", remoteDir, script)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(extractResult.Data) != "extracted" {
+		return fileError("upload directory", remoteDir, fmt.Errorf("failed to extract archive: %s", strings.TrimSpace(extractResult.Data)))
+	}
+
+	for _, entry := range pending {
+		result.Transferred = append(result.Transferred, entry.rel)
+		result.Bytes += entry.size
+	}
+	result.RequestID = extractResult.RequestID
+	return nil
+}
+
+// downloadArchive packs the pending files into a tar.gz archive in the session, downloads it
+// and extracts it into localDir
+func (fs *FileSystem) downloadArchive(ctx context.Context, remoteDir, localDir string, pending []*syncEntry, result *DirSyncResult) error {
+	archivePath, err := tempPath(remoteDir, ".tar.gz")
+	if err != nil {
+		return fileError("download directory", remoteDir, err)
+	}
+	listPath := archivePath + ".list"
+	names := make([]string, len(pending))
+	for i, entry := range pending {
+		names[i] = entry.rel
+	}
+	cleanup := "rm -f " + shellQuote(archivePath) + " " + shellQuote(listPath)
+	defer func() {
+		_, _ = fs.shell(context.Background(), "download directory", remoteDir, cleanup)
+	}()
+
+	if _, err := fs.WriteFileWithContext(ctx, listPath, strings.Join(names, "\n")+"\n", "overwrite"); err != nil {
+		return err
+	}
+	script := "tar -czf " + shellQuote(archivePath) + " -C " + shellQuote(remoteDir) +
+		" -T " + shellQuote(listPath) + " && echo packed"
+	packResult, err := fs.shell(ctx, "download directory", remoteDir, script)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(packResult.Data) != "packed" {
+		return fileError("download directory", remoteDir, fmt.Errorf("failed to create archive: %s", strings.TrimSpace(packResult.Data)))
+	}
+	readResult, err := fs.ReadFileBytesWithContext(ctx, archivePath)
+	if err != nil {
+		return err
+	}
+	if err := extractTarGz(bytes.NewReader(readResult.Content), localDir); err != nil {
+		return fileError("download directory", localDir, err)
+	}
+
+	for _, entry := range pending {
+		result.Transferred = append(result.Transferred, entry.rel)
+		result.Bytes += entry.size
+	}
+	result.RequestID = readResult.RequestID
+	return nil
+}
+
+// listRemoteDir returns the regular files below dir in the session by relative path. It
+// returns an error wrapping os.ErrNotExist if dir does not exist.
+func (fs *FileSystem) listRemoteDir(ctx context.Context, op, dir string, withHash bool) (map[string]*syncEntry, string, error) {
+	format := `%s\t%T@\t%P\n`
+	hashCmd := ""
+	if withHash {
+		format = `%s\t%T@\t%P\t`
+		hashCmd = ` -exec sh -c 'sha256sum < "$1"' _ {} \;`
+	}
+	script := "cd " + shellQuote(dir) + " 2>/dev/null || { echo missing; exit 0; }; " +
+		"find . -type f -printf '" + format + "'" + hashCmd
+	result, err := fs.shell(ctx, op, dir, script)
+	if err != nil {
+		return nil, "", err
+	}
+	if strings.TrimSpace(result.Data) == "missing" {
+		return nil, result.RequestID, fileError(op, dir, os.ErrNotExist)
+	}
+
+	entries := map[string]*syncEntry{}
+	for _, line := range strings.Split(result.Data, "\n") {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		entry := &syncEntry{rel: parts[2]}
+		if withHash {
+			index := strings.LastIndex(entry.rel, "\t")
+			if index < 0 {
+				continue
+			}
+			if fields := strings.Fields(entry.rel[index+1:]); len(fields) > 0 {
+				entry.sha256 = strings.ToLower(fields[0])
+			}
+			entry.rel = entry.rel[:index]
+		}
+		size, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		modTime, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			continue
+		}
+		entry.size, entry.modTime = size, int64(modTime)
+		entries[entry.rel] = entry
+	}
+	return entries, result.RequestID, nil
+}
+
+// listLocalDir returns the regular files below dir that pass the filters of options, sorted by
+// relative path
+func listLocalDir(dir string, options *DirSyncOptions) ([]*syncEntry, error) {
+	var entries []*syncEntry
+	err := filepath.WalkDir(dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, name)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if rel != "." && options.excludesDir(rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || !options.matches(rel) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		entry := &syncEntry{rel: rel, size: info.Size(), modTime: info.ModTime().Unix()}
+		if options.compare() == CompareHash {
+			if entry.sha256, err = localChecksum(name); err != nil {
+				return err
+			}
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	return entries, err
+}
+
+// localChecksum returns the hex encoded SHA-256 checksum of a local file
+func localChecksum(name string) (string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// sortedEntries returns the entries of m sorted by relative path
+func sortedEntries(m map[string]*syncEntry) []*syncEntry {
+	entries := make([]*syncEntry, 0, len(m))
+	for _, entry := range m {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].rel < entries[j].rel })
+	return entries
+}
+
+// writeLocalFile writes data to name, creating its parent directories, and sets its
+// modification time
+func writeLocalFile(name string, data []byte, modTime int64) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(name, data, 0o644); err != nil {
+		return err
+	}
+	mtime := time.Unix(modTime, 0)
+	return os.Chtimes(name, mtime, mtime)
+}
+
+// tempPath returns a random path for a temporary file next to dir
+func tempPath(dir, ext string) (string, error) {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to generate temporary file name: %w", err)
+	}
+	return strings.TrimSuffix(dir, "/") + ".agentbay-" + hex.EncodeToString(suffix) + ext, nil
+}
+
+// writeTarGz writes a tar.gz archive of the given files below localDir to w
+func writeTarGz(w io.Writer, localDir string, entries []*syncEntry) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, entry := range entries {
+		name := filepath.Join(localDir, filepath.FromSlash(entry.rel))
+		info, err := os.Stat(name)
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = entry.rel
+		// The writer rounds to whole seconds, while modification times are compared truncated
+		header.ModTime = info.ModTime().Truncate(time.Second)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(tw, file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// extractTarGz extracts the regular files and directories of a tar.gz archive into dir.
+// Entries that would be written outside dir are rejected.
+func extractTarGz(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		if !filepath.IsLocal(filepath.FromSlash(name)) {
+			return fmt.Errorf("archive entry %q is outside the target directory", header.Name)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return err
+			}
+			if err := writeLocalFile(target, data, header.ModTime.Unix()); err != nil {
+				return err
+			}
+		}
+	}
+}
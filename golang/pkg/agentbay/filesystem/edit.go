@@ -0,0 +1,204 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+)
+
+// Edit replaces the first occurrence of Old with New
+type Edit struct {
+	Old string `json:"oldText"`
+	New string `json:"newText"`
+}
+
+// HunkResult reports how a hunk of a diff was applied
+type HunkResult struct {
+	// Header is the hunk header, e.g. "@@ -12,4 +12,5 @@"
+	Header string
+	// OldStart and OldLines give the range of the hunk in the original file
+	OldStart, OldLines int
+	// NewStart and NewLines give the range of the hunk in the edited file
+	NewStart, NewLines int
+	// Applied reports whether the hunk applies to the file
+	Applied bool
+	// Offset is the number of lines the hunk was found away from the position in its header
+	Offset int
+	// Error describes why the hunk could not be applied
+	Error string
+}
+
+// EditResult is the result of ApplyEdits and ApplyPatch
+type EditResult struct {
+	models.ApiResponse
+	// Success reports whether all edits or hunks applied
+	Success bool
+	// DryRun reports that the file was not written
+	DryRun bool
+	// Diff is the unified diff between the original and the edited file
+	Diff string
+	// Hunks reports the status of every hunk. For ApplyEdits these are the hunks of Diff.
+	Hunks []*HunkResult
+}
+
+// ApplyEdits applies edits to the file at path in order. The file is read, edited locally and
+// written back unless dryRun is set. The result contains the unified diff of the change. If
+// the old text of an edit is not found, the file is left unchanged and an error is returned.
+func (fs *FileSystem) ApplyEdits(ctx context.Context, path string, edits []Edit, dryRun bool) (*EditResult, error) {
+	const op = "edit file"
+	file, err := fs.ReadFileWithContext(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	content := file.Content
+	for i, edit := range edits {
+		if edit.Old == "" {
+			return nil, fileError(op, path, fmt.Errorf("edit %d: old text must not be empty", i))
+		}
+		index := strings.Index(content, edit.Old)
+		if index < 0 {
+			return nil, fileError(op, path, fmt.Errorf("edit %d: old text not found", i))
+		}
+		content = content[:index] + edit.New + content[index+len(edit.Old):]
+	}
+
+	result := &EditResult{ApiResponse: file.ApiResponse, Success: true, DryRun: dryRun}
+	for _, hunk := range diffHunks(diffLines(splitLines(file.Content), splitLines(content))) {
+		result.Hunks = append(result.Hunks, hunkResult(hunk, true))
+	}
+	return fs.finishEdit(ctx, path, file.Content, content, result)
+}
+
+// ApplyPatch applies a unified diff of a single file, such as the output of diff -u or
+// git diff, to the file at path. The file headers of the patch are ignored. Hunks are located
+// at the position in their header or, like patch, at the nearest position where their context
+// matches. The file is read, patched locally and written back unless dryRun is set or a hunk
+// does not apply, in which case the result reports the failed hunks and is returned together
+// with an error.
+func (fs *FileSystem) ApplyPatch(ctx context.Context, path, patch string, dryRun bool) (*EditResult, error) {
+	const op = "apply patch"
+	hunks, err := parseUnifiedDiff(patch)
+	if err != nil {
+		return nil, fileError(op, path, err)
+	}
+	file, err := fs.ReadFileWithContext(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	content, results := applyHunks(splitLines(file.Content), hunks)
+	result := &EditResult{ApiResponse: file.ApiResponse, DryRun: dryRun, Hunks: results}
+	failed := 0
+	for _, hunk := range results {
+		if !hunk.Applied {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return result, fileError(op, path, fmt.Errorf("%d of %d hunks failed to apply", failed, len(results)))
+	}
+	result.Success = true
+	return fs.finishEdit(ctx, path, file.Content, content, result)
+}
+
+// finishEdit sets the diff of result and writes content unless the edit is a dry run
+func (fs *FileSystem) finishEdit(ctx context.Context, path, original, content string, result *EditResult) (*EditResult, error) {
+	result.Diff = UnifiedDiff(path, original, content)
+	if result.DryRun || content == original {
+		return result, nil
+	}
+	writeResult, err := fs.WriteFileWithContext(ctx, path, content, "overwrite")
+	if err != nil {
+		return nil, err
+	}
+	result.RequestID = writeResult.RequestID
+	return result, nil
+}
+
+// applyHunks applies hunks to lines in order and returns the patched content
+func applyHunks(lines []string, hunks []*diffHunk) (string, []*HunkResult) {
+	var b strings.Builder
+	results := make([]*HunkResult, 0, len(hunks))
+	pos, offset := 0, 0
+	for _, hunk := range hunks {
+		var old, replacement []string
+		for _, op := range hunk.ops {
+			if op.kind != '+' {
+				old = append(old, op.line)
+			}
+			if op.kind != '-' {
+				replacement = append(replacement, op.line)
+			}
+		}
+
+		result := hunkResult(hunk, false)
+		results = append(results, result)
+		// An empty range names the line before it
+		expected := hunk.oldStart - 1
+		if hunk.oldLines == 0 {
+			expected = hunk.oldStart
+		}
+		at, err := locateHunk(lines, old, pos, expected+offset)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+
+		result.Applied = true
+		result.Offset = at - expected
+		offset = result.Offset
+		for _, line := range lines[pos:at] {
+			b.WriteString(line)
+		}
+		for _, line := range replacement {
+			b.WriteString(line)
+		}
+		pos = at + len(old)
+	}
+	for _, line := range lines[pos:] {
+		b.WriteString(line)
+	}
+	return b.String(), results
+}
+
+// locateHunk returns the index of the occurrence of old in lines, at or after pos, that is
+// nearest to expected
+func locateHunk(lines, old []string, pos, expected int) (int, error) {
+	last := len(lines) - len(old)
+	if last < pos {
+		return 0, errors.New("hunk extends beyond the end of the file")
+	}
+	expected = min(max(expected, pos), last)
+	for distance := 0; expected-distance >= pos || expected+distance <= last; distance++ {
+		for _, at := range []int{expected - distance, expected + distance} {
+			if at >= pos && at <= last && matchesAt(lines, old, at) {
+				return at, nil
+			}
+		}
+	}
+	return 0, errors.New("hunk context does not match the file")
+}
+
+func matchesAt(lines, old []string, at int) bool {
+	for i, line := range old {
+		if lines[at+i] != line {
+			return false
+		}
+	}
+	return true
+}
+
+func hunkResult(hunk *diffHunk, applied bool) *HunkResult {
+	return &HunkResult{
+		Header:   hunk.header(),
+		OldStart: hunk.oldStart,
+		OldLines: hunk.oldLines,
+		NewStart: hunk.newStart,
+		NewLines: hunk.newLines,
+		Applied:  applied,
+	}
+}
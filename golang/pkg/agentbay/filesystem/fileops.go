@@ -0,0 +1,167 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	iofs "io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+)
+
+// commandDoneMarker is printed by runFileCommand after a command succeeded
+const commandDoneMarker = "__agentbay_done__"
+
+// Remove removes the file or empty directory at path. If recursive is set, directories are
+// removed with their contents and a missing path is not an error, like os.RemoveAll.
+func (fs *FileSystem) Remove(path string, recursive bool) (*FileWriteResult, error) {
+	return fs.RemoveWithContext(context.Background(), path, recursive)
+}
+
+// RemoveWithContext removes the file at path like Remove, honouring the cancellation and
+// deadline of ctx.
+func (fs *FileSystem) RemoveWithContext(ctx context.Context, path string, recursive bool) (*FileWriteResult, error) {
+	const op = "remove"
+	if err := validatePaths(op, path); err != nil {
+		return nil, err
+	}
+	quoted := shellQuote(path)
+	script := "if [ -d " + quoted + " ] && [ ! -L " + quoted + " ]; then rmdir -- " + quoted + "; else rm -- " + quoted + "; fi"
+	if recursive {
+		if cleanRemotePath(path) == "/" {
+			return nil, fileError(op, path, errors.New("refusing to remove the root directory"))
+		}
+		script = "rm -rf -- " + quoted
+	}
+	return fs.runFileCommand(ctx, op, path, script)
+}
+
+// Copy copies the file at src to dst, preserving its mode and modification time. If recursive
+// is set, directories are copied with their contents; otherwise copying a directory fails.
+func (fs *FileSystem) Copy(src, dst string, recursive bool) (*FileWriteResult, error) {
+	return fs.CopyWithContext(context.Background(), src, dst, recursive)
+}
+
+// CopyWithContext copies the file at src to dst like Copy, honouring the cancellation and
+// deadline of ctx.
+func (fs *FileSystem) CopyWithContext(ctx context.Context, src, dst string, recursive bool) (*FileWriteResult, error) {
+	const op = "copy"
+	if err := validatePaths(op, src, dst); err != nil {
+		return nil, err
+	}
+	flags := "-p"
+	if recursive {
+		flags = "-pR"
+	}
+	return fs.runFileCommand(ctx, op, src, "cp "+flags+" -- "+shellQuote(src)+" "+shellQuote(dst))
+}
+
+// Chmod changes the permissions of the file at path to the permission, setuid, setgid and
+// sticky bits of mode
+func (fs *FileSystem) Chmod(path string, mode os.FileMode) (*FileWriteResult, error) {
+	return fs.ChmodWithContext(context.Background(), path, mode)
+}
+
+// ChmodWithContext changes the permissions of the file at path like Chmod, honouring the
+// cancellation and deadline of ctx.
+func (fs *FileSystem) ChmodWithContext(ctx context.Context, path string, mode os.FileMode) (*FileWriteResult, error) {
+	const op = "chmod"
+	if err := validatePaths(op, path); err != nil {
+		return nil, err
+	}
+	bits := uint32(mode.Perm())
+	if mode&iofs.ModeSetuid != 0 {
+		bits |= 0o4000
+	}
+	if mode&iofs.ModeSetgid != 0 {
+		bits |= 0o2000
+	}
+	if mode&iofs.ModeSticky != 0 {
+		bits |= 0o1000
+	}
+	octal := "0" + strconv.FormatUint(uint64(bits), 8)
+	return fs.runFileCommand(ctx, op, path, "chmod "+octal+" -- "+shellQuote(path))
+}
+
+// Symlink creates link as a symbolic link to target. It fails with an error matching
+// fs.ErrExist if link already exists, even if it is a directory.
+func (fs *FileSystem) Symlink(target, link string) (*FileWriteResult, error) {
+	return fs.SymlinkWithContext(context.Background(), target, link)
+}
+
+// SymlinkWithContext creates link as a symbolic link to target like Symlink, honouring the
+// cancellation and deadline of ctx.
+func (fs *FileSystem) SymlinkWithContext(ctx context.Context, target, link string) (*FileWriteResult, error) {
+	const op = "symlink"
+	if err := validatePaths(op, target, link); err != nil {
+		return nil, err
+	}
+	// -T treats an existing directory as the link itself instead of creating the link inside it
+	return fs.runFileCommand(ctx, op, link, "ln -sT -- "+shellQuote(target)+" "+shellQuote(link))
+}
+
+// Stat returns information about the file at path, including its owner, mode and whether it is
+// a symbolic link, which is not followed. It fails with an error matching fs.ErrNotExist if the
+// path does not exist.
+func (fs *FileSystem) Stat(path string) (*FileInfoResult, error) {
+	return fs.StatWithContext(context.Background(), path)
+}
+
+// StatWithContext returns information about the file at path like Stat, honouring the
+// cancellation and deadline of ctx.
+func (fs *FileSystem) StatWithContext(ctx context.Context, path string) (*FileInfoResult, error) {
+	const op = "stat"
+	if err := validatePaths(op, path); err != nil {
+		return nil, err
+	}
+	infos, requestID, err := fs.find(ctx, op, path, "-maxdepth 0")
+	if err != nil {
+		return nil, err
+	}
+	if len(infos) == 0 {
+		return nil, fileError(op, path, os.ErrNotExist)
+	}
+	return &FileInfoResult{
+		ApiResponse: models.ApiResponse{
+			RequestID: requestID,
+		},
+		FileInfo: infos[0],
+	}, nil
+}
+
+// runFileCommand runs script with the shell tool. If the script fails, its output is returned
+// as a *models.ToolError wrapped in a *models.FileError, so that errors such as a missing file
+// match fs.ErrNotExist.
+func (fs *FileSystem) runFileCommand(ctx context.Context, op, path, script string) (*FileWriteResult, error) {
+	result, err := fs.shell(ctx, op, path, "{ "+script+"; } 2>&1 && echo "+commandDoneMarker)
+	if err != nil {
+		return nil, err
+	}
+
+	output := strings.TrimSpace(result.Data)
+	if !strings.HasSuffix(output, commandDoneMarker) {
+		if output == "" {
+			output = op + " failed"
+		}
+		return nil, fileError(op, path, &models.ToolError{Tool: "shell", Message: output, RequestID: result.RequestID})
+	}
+
+	return &FileWriteResult{
+		ApiResponse: models.ApiResponse{
+			RequestID: result.RequestID,
+		},
+		Success: true,
+	}, nil
+}
+
+// validatePaths returns an error if any of paths is empty
+func validatePaths(op string, paths ...string) error {
+	for _, p := range paths {
+		if strings.TrimSpace(p) == "" {
+			return fileError(op, p, errors.New("path must not be empty"))
+		}
+	}
+	return nil
+}
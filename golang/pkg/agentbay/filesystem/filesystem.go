@@ -1,6 +1,7 @@
 package filesystem
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	mcp "github.com/aliyun/wuying-agentbay-sdk/golang/api/client"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/logger"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
 )
 
@@ -160,6 +162,7 @@ type FileInfo struct {
 	Mode        string `json:"mode"`
 	Owner       string `json:"owner,omitempty"`
 	Group       string `json:"group,omitempty"`
+	IsSymlink   bool   `json:"isSymlink,omitempty"`
 }
 
 // DirectoryEntry represents a directory entry
@@ -257,6 +260,16 @@ func NewFileSystem(session interface {
 	}
 }
 
+// logger returns the logger of the owning session, falling back to logger.Default()
+func (fs *FileSystem) logger() logger.Logger {
+	return logger.From(fs.Session)
+}
+
+// fileError wraps err as a *models.FileError describing the failed operation
+func fileError(op, path string, err error) error {
+	return &models.FileError{Op: op, Path: path, Err: err}
+}
+
 // CreateDirectory creates a new directory.
 func (fs *FileSystem) CreateDirectory(path string) (*FileDirectoryResult, error) {
 	args := map[string]string{
@@ -265,11 +278,11 @@ func (fs *FileSystem) CreateDirectory(path string) (*FileDirectoryResult, error)
 
 	result, err := fs.Session.CallMcpTool("create_directory", args)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create directory: %w", err)
+		return nil, fileError("create directory", path, err)
 	}
 
 	if !result.Success {
-		return nil, fmt.Errorf("create directory failed: %s", result.ErrorMessage)
+		return nil, fileError("create directory", path, models.NewToolError("create_directory", result))
 	}
 
 	return &FileDirectoryResult{
@@ -280,7 +293,8 @@ func (fs *FileSystem) CreateDirectory(path string) (*FileDirectoryResult, error)
 	}, nil
 }
 
-// EditFile edits a file with specified changes.
+// EditFile edits a file with specified changes. ApplyEdits accepts typed edits and returns the
+// diff of the change.
 func (fs *FileSystem) EditFile(path string, edits []map[string]string, dryRun bool) (*FileWriteResult, error) {
 	args := map[string]interface{}{
 		"path":    path,
@@ -290,11 +304,11 @@ func (fs *FileSystem) EditFile(path string, edits []map[string]string, dryRun bo
 
 	result, err := fs.Session.CallMcpTool("edit_file", args)
 	if err != nil {
-		return nil, fmt.Errorf("failed to edit file: %w", err)
+		return nil, fileError("edit file", path, err)
 	}
 
 	if !result.Success {
-		return nil, fmt.Errorf("edit file failed: %s", result.ErrorMessage)
+		return nil, fileError("edit file", path, models.NewToolError("edit_file", result))
 	}
 
 	return &FileWriteResult{
@@ -307,26 +321,28 @@ func (fs *FileSystem) EditFile(path string, edits []map[string]string, dryRun bo
 
 // GetFileInfo gets information about a file or directory.
 func (fs *FileSystem) GetFileInfo(path string) (*FileInfoResult, error) {
+	return fs.GetFileInfoWithContext(context.Background(), path)
+}
+
+// GetFileInfoWithContext gets information about a file or directory, honouring the
+// cancellation and deadline of ctx.
+func (fs *FileSystem) GetFileInfoWithContext(ctx context.Context, path string) (*FileInfoResult, error) {
 	args := map[string]string{
 		"path": path,
 	}
 
-	result, err := fs.Session.CallMcpTool("get_file_info", args)
+	result, err := models.CallMcpToolWithContext(ctx, fs.Session, "get_file_info", args)
 	if err != nil {
-		// Check if it's a "file not found" error
-		if strings.Contains(err.Error(), "No such file or directory") {
-			return nil, fmt.Errorf("file not found: %s", path)
-		}
-		return nil, fmt.Errorf("failed to get file info: %w", err)
+		return nil, fileError("get file info", path, err)
 	}
 
 	if !result.Success {
-		return nil, fmt.Errorf("get file info failed: %s", result.ErrorMessage)
+		return nil, fileError("get file info", path, models.NewToolError("get_file_info", result))
 	}
 
 	fileInfo, err := parseFileInfo(result.Data)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing file info: %w", err)
+		return nil, fileError("get file info", path, fmt.Errorf("error parsing file info: %w", err))
 	}
 
 	return &FileInfoResult{
@@ -339,22 +355,28 @@ func (fs *FileSystem) GetFileInfo(path string) (*FileInfoResult, error) {
 
 // ListDirectory lists the contents of a directory.
 func (fs *FileSystem) ListDirectory(path string) (*DirectoryListResult, error) {
+	return fs.ListDirectoryWithContext(context.Background(), path)
+}
+
+// ListDirectoryWithContext lists the contents of a directory, honouring the cancellation and
+// deadline of ctx.
+func (fs *FileSystem) ListDirectoryWithContext(ctx context.Context, path string) (*DirectoryListResult, error) {
 	args := map[string]string{
 		"path": path,
 	}
 
-	result, err := fs.Session.CallMcpTool("list_directory", args)
+	result, err := models.CallMcpToolWithContext(ctx, fs.Session, "list_directory", args)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list directory: %w", err)
+		return nil, fileError("list directory", path, err)
 	}
 
 	if !result.Success {
-		return nil, fmt.Errorf("list directory failed: %s", result.ErrorMessage)
+		return nil, fileError("list directory", path, models.NewToolError("list_directory", result))
 	}
 
 	entries, err := parseDirectoryListing(result.Data)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing directory listing: %w", err)
+		return nil, fileError("list directory", path, fmt.Errorf("error parsing directory listing: %w", err))
 	}
 
 	return &DirectoryListResult{
@@ -374,11 +396,11 @@ func (fs *FileSystem) MoveFile(source, destination string) (*FileWriteResult, er
 
 	result, err := fs.Session.CallMcpTool("move_file", args)
 	if err != nil {
-		return nil, fmt.Errorf("failed to move file: %w", err)
+		return nil, fileError("move file", source, err)
 	}
 
 	if !result.Success {
-		return nil, fmt.Errorf("move file failed: %s", result.ErrorMessage)
+		return nil, fileError("move file", source, models.NewToolError("move_file", result))
 	}
 
 	return &FileWriteResult{
@@ -390,7 +412,7 @@ func (fs *FileSystem) MoveFile(source, destination string) (*FileWriteResult, er
 }
 
 // readFileChunk reads a file chunk. Internal method used for chunked file operations.
-func (fs *FileSystem) readFileChunk(path string, optionalParams ...int) (*FileReadResult, error) {
+func (fs *FileSystem) readFileChunk(ctx context.Context, path string, optionalParams ...int) (*FileReadResult, error) {
 	// Handle optional parameters for backward compatibility
 	offset, length := 0, 0
 	if len(optionalParams) > 0 {
@@ -410,13 +432,13 @@ func (fs *FileSystem) readFileChunk(path string, optionalParams ...int) (*FileRe
 		args["length"] = length
 	}
 
-	result, err := fs.Session.CallMcpTool("read_file", args)
+	result, err := models.CallMcpToolWithContext(ctx, fs.Session, "read_file", args)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+		return nil, fileError("read file", path, err)
 	}
 
 	if !result.Success {
-		return nil, fmt.Errorf("read file failed: %s", result.ErrorMessage)
+		return nil, fileError("read file", path, models.NewToolError("read_file", result))
 	}
 
 	return &FileReadResult{
@@ -435,11 +457,11 @@ func (fs *FileSystem) ReadMultipleFiles(paths []string) (map[string]string, erro
 
 	result, err := fs.Session.CallMcpTool("read_multiple_files", args)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read multiple files: %w", err)
+		return nil, fileError("read multiple files", "", err)
 	}
 
 	if !result.Success {
-		return nil, fmt.Errorf("read multiple files failed: %s", result.ErrorMessage)
+		return nil, fileError("read multiple files", "", models.NewToolError("read_multiple_files", result))
 	}
 
 	// Parse the result - format is "path:\ncontent\n\n---\npath2:\ncontent2"
@@ -485,11 +507,11 @@ func (fs *FileSystem) SearchFiles(path, pattern string, excludePatterns []string
 
 	result, err := fs.Session.CallMcpTool("search_files", args)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search files: %w", err)
+		return nil, fileError("search files", path, err)
 	}
 
 	if !result.Success {
-		return nil, fmt.Errorf("search files failed: %s", result.ErrorMessage)
+		return nil, fileError("search files", path, models.NewToolError("search_files", result))
 	}
 
 	// Parse the result
@@ -510,7 +532,7 @@ func (fs *FileSystem) SearchFiles(path, pattern string, excludePatterns []string
 }
 
 // writeFileChunk writes a file chunk. Internal method used for chunked file operations.
-func (fs *FileSystem) writeFileChunk(path, content string, mode string) (*FileWriteResult, error) {
+func (fs *FileSystem) writeFileChunk(ctx context.Context, path, content string, mode string) (*FileWriteResult, error) {
 	// Validate mode parameter
 	if mode != "" && mode != "overwrite" && mode != "append" {
 		return nil, fmt.Errorf("invalid write mode: %s. Must be 'overwrite' or 'append'", mode)
@@ -524,13 +546,13 @@ func (fs *FileSystem) writeFileChunk(path, content string, mode string) (*FileWr
 		args["mode"] = mode
 	}
 
-	result, err := fs.Session.CallMcpTool("write_file", args)
+	result, err := models.CallMcpToolWithContext(ctx, fs.Session, "write_file", args)
 	if err != nil {
-		return nil, fmt.Errorf("failed to write file: %w", err)
+		return nil, fileError("write file", path, err)
 	}
 
 	if !result.Success {
-		return nil, fmt.Errorf("write file failed: %s", result.ErrorMessage)
+		return nil, fileError("write file", path, models.NewToolError("write_file", result))
 	}
 
 	return &FileWriteResult{
@@ -545,11 +567,18 @@ func (fs *FileSystem) writeFileChunk(path, content string, mode string) (*FileWr
 const ChunkSize = 50 * 1024
 
 // ReadFile reads the contents of a file. Automatically handles large files by chunking.
+// Chunks are split at byte offsets, so binary files should be read with ReadFileBytes.
 func (fs *FileSystem) ReadFile(path string) (*FileReadResult, error) {
+	return fs.ReadFileWithContext(context.Background(), path)
+}
+
+// ReadFileWithContext reads the contents of a file like ReadFile, stopping between chunks
+// and aborting in-flight requests when ctx is cancelled or its deadline expires.
+func (fs *FileSystem) ReadFileWithContext(ctx context.Context, path string) (*FileReadResult, error) {
 	chunkSize := ChunkSize
 
 	// First get the file size
-	fileInfoResult, err := fs.GetFileInfo(path)
+	fileInfoResult, err := fs.GetFileInfoWithContext(ctx, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get file info: %w", err)
 	}
@@ -558,7 +587,11 @@ func (fs *FileSystem) ReadFile(path string) (*FileReadResult, error) {
 	size := fileInfoResult.FileInfo.Size
 
 	if size == 0 {
-		return nil, fmt.Errorf("couldn't determine file size")
+		return &FileReadResult{
+			ApiResponse: models.ApiResponse{
+				RequestID: fileInfoResult.RequestID,
+			},
+		}, nil
 	}
 
 	// Prepare to read the file in chunks
@@ -566,8 +599,8 @@ func (fs *FileSystem) ReadFile(path string) (*FileReadResult, error) {
 	offset := 0
 	fileSize := int(size)
 
-	fmt.Printf("ReadFile: Starting chunked read of %s (total size: %d bytes, chunk size: %d bytes)\n",
-		path, fileSize, chunkSize)
+	log := fs.logger()
+	log.Debug("ReadFile: starting chunked read", "path", path, "size", fileSize, "chunk_size", chunkSize)
 
 	chunkCount := 0
 	var lastRequestID string
@@ -578,11 +611,10 @@ func (fs *FileSystem) ReadFile(path string) (*FileReadResult, error) {
 			length = fileSize - offset
 		}
 
-		fmt.Printf("ReadFile: Reading chunk %d (%d bytes at offset %d/%d)\n",
-			chunkCount+1, length, offset, fileSize)
+		log.Debug("ReadFile: reading chunk", "path", path, "chunk", chunkCount+1, "length", length, "offset", offset, "size", fileSize)
 
 		// Read the chunk
-		chunkResult, err := fs.readFileChunk(path, offset, length)
+		chunkResult, err := fs.readFileChunk(ctx, path, offset, length)
 		if err != nil {
 			return nil, fmt.Errorf("error reading chunk at offset %d: %w", offset, err)
 		}
@@ -596,8 +628,7 @@ func (fs *FileSystem) ReadFile(path string) (*FileReadResult, error) {
 		chunkCount++
 	}
 
-	fmt.Printf("ReadFile: Successfully read %s in %d chunks (total: %d bytes)\n",
-		path, chunkCount, fileSize)
+	log.Debug("ReadFile: read complete", "path", path, "chunks", chunkCount, "size", fileSize)
 
 	return &FileReadResult{
 		ApiResponse: models.ApiResponse{
@@ -608,18 +639,24 @@ func (fs *FileSystem) ReadFile(path string) (*FileReadResult, error) {
 }
 
 // WriteFile writes content to a file. Automatically handles large files by chunking.
+// Binary data should be written with WriteFileBytes.
 func (fs *FileSystem) WriteFile(path, content string, mode string) (*FileWriteResult, error) {
+	return fs.WriteFileWithContext(context.Background(), path, content, mode)
+}
+
+// WriteFileWithContext writes content to a file like WriteFile, stopping between chunks
+// and aborting in-flight requests when ctx is cancelled or its deadline expires.
+func (fs *FileSystem) WriteFileWithContext(ctx context.Context, path, content string, mode string) (*FileWriteResult, error) {
 	chunkSize := ChunkSize
 	contentLen := len(content)
 
-	fmt.Printf("WriteFile: Starting write to %s (total size: %d bytes, chunk size: %d bytes)\n",
-		path, contentLen, chunkSize)
+	log := fs.logger()
+	log.Debug("WriteFile: starting write", "path", path, "size", contentLen, "chunk_size", chunkSize)
 
 	// If content is small enough, use the regular writeFileChunk method
 	if contentLen <= chunkSize {
-		fmt.Printf("WriteFile: Content size (%d bytes) is smaller than chunk size, using normal writeFileChunk\n",
-			contentLen)
-		return fs.writeFileChunk(path, content, mode)
+		log.Debug("WriteFile: content fits in a single chunk", "path", path, "size", contentLen)
+		return fs.writeFileChunk(ctx, path, content, mode)
 	}
 
 	// Write the first chunk with the specified mode
@@ -628,8 +665,8 @@ func (fs *FileSystem) WriteFile(path, content string, mode string) (*FileWriteRe
 		firstChunkEnd = contentLen
 	}
 
-	fmt.Printf("WriteFile: Writing first chunk (0-%d bytes) with %s mode\n", firstChunkEnd, mode)
-	result, err := fs.writeFileChunk(path, content[:firstChunkEnd], mode)
+	log.Debug("WriteFile: writing first chunk", "path", path, "end", firstChunkEnd, "mode", mode)
+	result, err := fs.writeFileChunk(ctx, path, content[:firstChunkEnd], mode)
 	if err != nil {
 		return nil, fmt.Errorf("error writing first chunk: %w", err)
 	}
@@ -642,10 +679,9 @@ func (fs *FileSystem) WriteFile(path, content string, mode string) (*FileWriteRe
 			end = contentLen
 		}
 
-		fmt.Printf("WriteFile: Writing chunk %d (%d-%d bytes) with append mode\n",
-			chunkCount+1, offset, end)
+		log.Debug("WriteFile: writing chunk", "path", path, "chunk", chunkCount+1, "offset", offset, "end", end)
 
-		result, err = fs.writeFileChunk(path, content[offset:end], "append")
+		result, err = fs.writeFileChunk(ctx, path, content[offset:end], "append")
 		if err != nil {
 			return nil, fmt.Errorf("error writing chunk at offset %d: %w", offset, err)
 		}
@@ -654,8 +690,7 @@ func (fs *FileSystem) WriteFile(path, content string, mode string) (*FileWriteRe
 		chunkCount++
 	}
 
-	fmt.Printf("WriteFile: Successfully wrote %s in %d chunks (total: %d bytes)\n",
-		path, chunkCount, contentLen)
+	log.Debug("WriteFile: write complete", "path", path, "chunks", chunkCount, "size", contentLen)
 
 	return result, nil
 }
@@ -679,13 +714,19 @@ func parseFileChangeData(rawData string) ([]*FileChangeEvent, error) {
 
 // GetFileChange gets file change information for the specified directory path
 func (fs *FileSystem) GetFileChange(path string) (*FileChangeResult, error) {
+	return fs.GetFileChangeWithContext(context.Background(), path)
+}
+
+// GetFileChangeWithContext gets file change information like GetFileChange, honouring the
+// cancellation and deadline of ctx.
+func (fs *FileSystem) GetFileChangeWithContext(ctx context.Context, path string) (*FileChangeResult, error) {
 	args := map[string]string{
 		"path": path,
 	}
 
-	result, err := fs.Session.CallMcpTool("get_file_change", args)
+	result, err := models.CallMcpToolWithContext(ctx, fs.Session, "get_file_change", args)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get file change: %w", err)
+		return nil, fileError("get file change", path, err)
 	}
 
 	if !result.Success {
@@ -694,7 +735,7 @@ func (fs *FileSystem) GetFileChange(path string) (*FileChangeResult, error) {
 				RequestID: result.RequestID,
 			},
 			RawData: result.Data,
-		}, fmt.Errorf("get file change failed: %s", result.ErrorMessage)
+		}, fileError("get file change", path, models.NewToolError("get_file_change", result))
 	}
 
 	// Parse the file change events
@@ -705,7 +746,7 @@ func (fs *FileSystem) GetFileChange(path string) (*FileChangeResult, error) {
 				RequestID: result.RequestID,
 			},
 			RawData: result.Data,
-		}, fmt.Errorf("failed to parse file change data: %w", err)
+		}, fileError("get file change", path, fmt.Errorf("failed to parse file change data: %w", err))
 	}
 
 	return &FileChangeResult{
@@ -723,58 +764,17 @@ func (fs *FileSystem) WatchDirectoryWithDefaults(
 	callback func([]*FileChangeEvent),
 	stopCh <-chan struct{},
 ) *sync.WaitGroup {
-	return fs.WatchDirectory(path, callback, 500*time.Millisecond, stopCh)
+	return fs.WatchDirectory(path, callback, DefaultWatchInterval, stopCh)
 }
 
-// WatchDirectory watches a directory for file changes and calls the callback function when changes occur
+// WatchDirectory watches a directory for file changes and calls the callback function when changes occur.
+// The callback is called on a single goroutine, one batch of events at a time. Use Watch for a
+// channel based API with filtering and debouncing.
 func (fs *FileSystem) WatchDirectory(
 	path string,
 	callback func([]*FileChangeEvent),
 	interval time.Duration,
 	stopCh <-chan struct{},
 ) *sync.WaitGroup {
-	var wg sync.WaitGroup
-	wg.Add(1)
-
-	go func() {
-		defer wg.Done()
-		fmt.Printf("Starting directory monitoring for: %s\n", path)
-		fmt.Printf("Polling interval: %v\n", interval)
-
-		ticker := time.NewTicker(interval)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-stopCh:
-				fmt.Printf("Stopped monitoring directory: %s\n", path)
-				return
-			case <-ticker.C:
-				result, err := fs.GetFileChange(path)
-				if err != nil {
-					fmt.Printf("Error monitoring directory: %v\n", err)
-					continue
-				}
-
-				if len(result.Events) > 0 {
-					fmt.Printf("Detected %d file changes:\n", len(result.Events))
-					for _, event := range result.Events {
-						fmt.Printf("  - %s\n", event.String())
-					}
-
-					// Call callback in a separate goroutine to avoid blocking
-					go func(events []*FileChangeEvent) {
-						defer func() {
-							if r := recover(); r != nil {
-								fmt.Printf("Error in callback function: %v\n", r)
-							}
-						}()
-						callback(events)
-					}(result.Events)
-				}
-			}
-		}
-	}()
-
-	return &wg
+	return fs.watchDirectory(path, callback, interval, stopCh)
 }
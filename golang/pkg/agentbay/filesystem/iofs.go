@@ -0,0 +1,304 @@
+package filesystem
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	iofs "io/fs"
+	"path"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FSOptions configures the fs.FS returned by FSWithOptions
+type FSOptions struct {
+	// CacheTTL keeps file information, directory listings and file contents for the given
+	// duration, so that repeated lookups do not call the session again. If zero, nothing is
+	// cached.
+	CacheTTL time.Duration
+	// Binary reads file contents with ReadFileBytes instead of ReadFile, which is required for
+	// files that are not UTF-8 text
+	Binary bool
+}
+
+// SessionFS exposes a directory of the session as an fs.FS. It implements fs.ReadDirFS,
+// fs.StatFS and fs.ReadFileFS, so it can be passed to fs.WalkDir, template.ParseFS or
+// http.FS. Files are read completely when opened.
+type SessionFS struct {
+	fs      *FileSystem
+	ctx     context.Context
+	root    string
+	options FSOptions
+
+	mu      sync.Mutex
+	infos   map[string]cacheEntry[*FileInfo]
+	dirs    map[string]cacheEntry[[]*DirectoryEntry]
+	content map[string]cacheEntry[[]byte]
+}
+
+// cacheEntry is a cached value with its expiry time
+type cacheEntry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+var (
+	_ iofs.ReadDirFS  = (*SessionFS)(nil)
+	_ iofs.StatFS     = (*SessionFS)(nil)
+	_ iofs.ReadFileFS = (*SessionFS)(nil)
+)
+
+// FS returns an fs.FS for the directory root of the session, without caching
+func (fs *FileSystem) FS(root string) *SessionFS {
+	return fs.FSWithOptions(context.Background(), root, nil)
+}
+
+// FSWithOptions returns an fs.FS for the directory root of the session. All calls of the
+// returned file system use ctx. options may be nil.
+func (fs *FileSystem) FSWithOptions(ctx context.Context, root string, options *FSOptions) *SessionFS {
+	sessionFS := &SessionFS{
+		fs:      fs,
+		ctx:     ctx,
+		root:    path.Clean(root),
+		infos:   map[string]cacheEntry[*FileInfo]{},
+		dirs:    map[string]cacheEntry[[]*DirectoryEntry]{},
+		content: map[string]cacheEntry[[]byte]{},
+	}
+	if options != nil {
+		sessionFS.options = *options
+	}
+	return sessionFS
+}
+
+// Invalidate drops all cached entries
+func (s *SessionFS) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.infos)
+	clear(s.dirs)
+	clear(s.content)
+}
+
+// Open implements fs.FS
+func (s *SessionFS) Open(name string) (iofs.File, error) {
+	info, err := s.stat("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return &sessionDir{fs: s, name: name, info: info}, nil
+	}
+	data, err := s.readFile("open", name)
+	if err != nil {
+		return nil, err
+	}
+	return &sessionFile{Reader: bytes.NewReader(data), info: info}, nil
+}
+
+// Stat implements fs.StatFS
+func (s *SessionFS) Stat(name string) (iofs.FileInfo, error) {
+	return s.stat("stat", name)
+}
+
+// ReadFile implements fs.ReadFileFS. The returned slice may be modified by the caller.
+func (s *SessionFS) ReadFile(name string) ([]byte, error) {
+	data, err := s.readFile("readfile", name)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Clone(data), nil
+}
+
+// ReadDir implements fs.ReadDirFS
+func (s *SessionFS) ReadDir(name string) ([]iofs.DirEntry, error) {
+	if !iofs.ValidPath(name) {
+		return nil, &iofs.PathError{Op: "readdir", Path: name, Err: iofs.ErrInvalid}
+	}
+	full := s.fullPath(name)
+
+	s.mu.Lock()
+	cached, ok := s.dirs[full]
+	s.mu.Unlock()
+	entries := cached.value
+	if !ok || time.Now().After(cached.expires) {
+		result, err := s.fs.ListDirectoryWithContext(s.ctx, full)
+		if err != nil {
+			return nil, &iofs.PathError{Op: "readdir", Path: name, Err: err}
+		}
+		entries = result.Entries
+		s.store(func() { s.dirs[full] = cacheEntry[[]*DirectoryEntry]{entries, s.expiry()} })
+	}
+
+	dirEntries := make([]iofs.DirEntry, 0, len(entries))
+	for _, entry := range entries {
+		dirEntries = append(dirEntries, &sessionDirEntry{fs: s, name: path.Join(name, entry.Name), entry: entry})
+	}
+	sort.Slice(dirEntries, func(i, j int) bool { return dirEntries[i].Name() < dirEntries[j].Name() })
+	return dirEntries, nil
+}
+
+// stat returns the file information of name, from the cache if possible
+func (s *SessionFS) stat(op, name string) (*sessionFileInfo, error) {
+	if !iofs.ValidPath(name) {
+		return nil, &iofs.PathError{Op: op, Path: name, Err: iofs.ErrInvalid}
+	}
+	full := s.fullPath(name)
+
+	s.mu.Lock()
+	cached, ok := s.infos[full]
+	s.mu.Unlock()
+	info := cached.value
+	if !ok || time.Now().After(cached.expires) {
+		result, err := s.fs.GetFileInfoWithContext(s.ctx, full)
+		if err != nil {
+			return nil, &iofs.PathError{Op: op, Path: name, Err: err}
+		}
+		info = result.FileInfo
+		s.store(func() { s.infos[full] = cacheEntry[*FileInfo]{info, s.expiry()} })
+	}
+	return &sessionFileInfo{name: path.Base(full), info: info}, nil
+}
+
+// readFile returns the contents of name, from the cache if possible
+func (s *SessionFS) readFile(op, name string) ([]byte, error) {
+	if !iofs.ValidPath(name) {
+		return nil, &iofs.PathError{Op: op, Path: name, Err: iofs.ErrInvalid}
+	}
+	full := s.fullPath(name)
+
+	s.mu.Lock()
+	cached, ok := s.content[full]
+	s.mu.Unlock()
+	if ok && !time.Now().After(cached.expires) {
+		return cached.value, nil
+	}
+
+	var data []byte
+	if s.options.Binary {
+		result, err := s.fs.ReadFileBytesWithContext(s.ctx, full)
+		if err != nil {
+			return nil, &iofs.PathError{Op: op, Path: name, Err: err}
+		}
+		data = result.Content
+	} else {
+		result, err := s.fs.ReadFileWithContext(s.ctx, full)
+		if err != nil {
+			return nil, &iofs.PathError{Op: op, Path: name, Err: err}
+		}
+		data = []byte(result.Content)
+	}
+	s.store(func() { s.content[full] = cacheEntry[[]byte]{data, s.expiry()} })
+	return data, nil
+}
+
+// store runs update with the cache locked, unless caching is disabled
+func (s *SessionFS) store(update func()) {
+	if s.options.CacheTTL <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	update()
+}
+
+func (s *SessionFS) expiry() time.Time {
+	return time.Now().Add(s.options.CacheTTL)
+}
+
+// fullPath returns the path in the session of the valid fs.FS path name
+func (s *SessionFS) fullPath(name string) string {
+	return path.Join(s.root, name)
+}
+
+// sessionFileInfo implements fs.FileInfo for a FileInfo
+type sessionFileInfo struct {
+	name string
+	info *FileInfo
+}
+
+func (i *sessionFileInfo) Name() string       { return i.name }
+func (i *sessionFileInfo) Size() int64        { return i.info.Size }
+func (i *sessionFileInfo) ModTime() time.Time { return i.info.ModTimeValue() }
+func (i *sessionFileInfo) IsDir() bool        { return i.info.IsDirectory }
+func (i *sessionFileInfo) Sys() any           { return i.info }
+
+func (i *sessionFileInfo) Mode() iofs.FileMode {
+	mode := i.info.FileMode()
+	if i.info.IsDirectory {
+		mode |= iofs.ModeDir
+	}
+	return mode
+}
+
+// sessionFile is an open regular file of a SessionFS
+type sessionFile struct {
+	*bytes.Reader
+	info *sessionFileInfo
+}
+
+func (f *sessionFile) Stat() (iofs.FileInfo, error) { return f.info, nil }
+func (f *sessionFile) Close() error                 { return nil }
+
+// sessionDir is an open directory of a SessionFS
+type sessionDir struct {
+	fs      *SessionFS
+	name    string
+	info    *sessionFileInfo
+	entries []iofs.DirEntry
+	read    bool
+}
+
+func (d *sessionDir) Stat() (iofs.FileInfo, error) { return d.info, nil }
+func (d *sessionDir) Close() error                 { return nil }
+
+func (d *sessionDir) Read([]byte) (int, error) {
+	return 0, &iofs.PathError{Op: "read", Path: d.name, Err: errors.New("is a directory")}
+}
+
+// ReadDir implements fs.ReadDirFile
+func (d *sessionDir) ReadDir(n int) ([]iofs.DirEntry, error) {
+	if !d.read {
+		entries, err := d.fs.ReadDir(d.name)
+		if err != nil {
+			return nil, err
+		}
+		d.entries, d.read = entries, true
+	}
+	if n <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(d.entries) {
+		n = len(d.entries)
+	}
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
+
+// sessionDirEntry implements fs.DirEntry for a DirectoryEntry. Info calls Stat.
+type sessionDirEntry struct {
+	fs    *SessionFS
+	name  string
+	entry *DirectoryEntry
+}
+
+func (e *sessionDirEntry) Name() string { return e.entry.Name }
+func (e *sessionDirEntry) IsDir() bool  { return e.entry.IsDirectory }
+
+func (e *sessionDirEntry) Type() iofs.FileMode {
+	if e.entry.IsDirectory {
+		return iofs.ModeDir
+	}
+	return 0
+}
+
+func (e *sessionDirEntry) Info() (iofs.FileInfo, error) {
+	return e.fs.Stat(e.name)
+}
@@ -0,0 +1,227 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+// ErrClosed is returned when a stream returned by Open or Create is used after Close
+var ErrClosed = errors.New("file stream is closed")
+
+// ProgressFunc is called after each chunk transferred by a file stream with the number of bytes
+// transferred so far and the total size, or -1 if the total size is not known
+type ProgressFunc func(transferred, total int64)
+
+// StreamOptions configures the file streams returned by OpenWithContext and CreateWithContext
+type StreamOptions struct {
+	// ChunkSize is the number of bytes transferred per call. Defaults to ChunkSize.
+	ChunkSize int
+	// Progress is called after each chunk, if set
+	Progress ProgressFunc
+}
+
+func (o *StreamOptions) chunkSize() int {
+	if o == nil || o.ChunkSize <= 0 {
+		return ChunkSize
+	}
+	return o.ChunkSize
+}
+
+func (o *StreamOptions) progress(transferred, total int64) {
+	if o != nil && o.Progress != nil {
+		o.Progress(transferred, total)
+	}
+}
+
+// fileReader streams a file from the session one chunk at a time
+type fileReader struct {
+	fs      *FileSystem
+	ctx     context.Context
+	path    string
+	options *StreamOptions
+	size    int64
+	offset  int64
+	buf     []byte
+	closed  bool
+}
+
+// Open opens a file in the session for streamed reading. At most one chunk of the file is held
+// in memory at a time. Like ReadFile, the file is read as text.
+func (fs *FileSystem) Open(path string) (io.ReadCloser, error) {
+	return fs.OpenWithContext(context.Background(), path, nil)
+}
+
+// OpenWithContext opens a file for streamed reading like Open. Reads fail once ctx is cancelled
+// or its deadline expires. options may be nil.
+func (fs *FileSystem) OpenWithContext(ctx context.Context, path string, options *StreamOptions) (io.ReadCloser, error) {
+	fileInfoResult, err := fs.GetFileInfoWithContext(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	if fileInfoResult.FileInfo.IsDirectory {
+		return nil, fileError("open file", path, errors.New("is a directory"))
+	}
+
+	return &fileReader{
+		fs:      fs,
+		ctx:     ctx,
+		path:    path,
+		options: options,
+		size:    fileInfoResult.FileInfo.Size,
+	}, nil
+}
+
+// Read implements io.Reader
+func (r *fileReader) Read(p []byte) (int, error) {
+	if r.closed {
+		return 0, fileError("read file", r.path, ErrClosed)
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	for len(r.buf) == 0 {
+		if r.offset >= r.size {
+			return 0, io.EOF
+		}
+		length := int64(r.options.chunkSize())
+		if r.offset+length > r.size {
+			length = r.size - r.offset
+		}
+		chunkResult, err := r.fs.readFileChunk(r.ctx, r.path, int(r.offset), int(length))
+		if err != nil {
+			return 0, err
+		}
+		r.buf = []byte(chunkResult.Content)
+		r.offset += length
+		r.options.progress(r.offset, r.size)
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// Close implements io.Closer
+func (r *fileReader) Close() error {
+	r.closed = true
+	r.buf = nil
+	return nil
+}
+
+// fileWriter streams data to a file in the session one chunk at a time
+type fileWriter struct {
+	fs      *FileSystem
+	ctx     context.Context
+	path    string
+	options *StreamOptions
+	buf     []byte
+	written int64
+	err     error
+	closed  bool
+}
+
+// Create creates or truncates a file in the session and returns a writer that streams data to
+// it. Data is sent whenever a full chunk has been buffered and on Close, so at most one chunk is
+// held in memory. Chunks are split at UTF-8 character boundaries; binary data should be written
+// with WriteFileBytes.
+func (fs *FileSystem) Create(path string) (io.WriteCloser, error) {
+	return fs.CreateWithContext(context.Background(), path, nil)
+}
+
+// CreateWithContext creates a file for streamed writing like Create. Writes fail once ctx is
+// cancelled or its deadline expires. options may be nil.
+func (fs *FileSystem) CreateWithContext(ctx context.Context, path string, options *StreamOptions) (io.WriteCloser, error) {
+	if _, err := fs.writeFileChunk(ctx, path, "", "overwrite"); err != nil {
+		return nil, err
+	}
+
+	return &fileWriter{
+		fs:      fs,
+		ctx:     ctx,
+		path:    path,
+		options: options,
+	}, nil
+}
+
+// Write implements io.Writer
+func (w *fileWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, fileError("write file", w.path, ErrClosed)
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	chunkSize := w.options.chunkSize()
+	written := 0
+	for len(p) > 0 {
+		// The buffer may exceed chunkSize by an incomplete UTF-8 sequence held back by flush
+		n := chunkSize - len(w.buf)
+		if n < 1 {
+			n = 1
+		}
+		if n > len(p) {
+			n = len(p)
+		}
+		w.buf = append(w.buf, p[:n]...)
+		p = p[n:]
+		written += n
+		if len(w.buf) >= chunkSize {
+			if err := w.flush(false); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// Close sends the remaining buffered data and implements io.Closer
+func (w *fileWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if w.err != nil {
+		return w.err
+	}
+	return w.flush(true)
+}
+
+// flush appends the buffered data to the file. Unless final is set, an incomplete UTF-8
+// sequence at the end of the buffer is kept for the next chunk.
+func (w *fileWriter) flush(final bool) error {
+	end := len(w.buf)
+	if !final {
+		end = lastRuneBoundary(w.buf)
+	}
+	if end == 0 {
+		return nil
+	}
+
+	if _, err := w.fs.writeFileChunk(w.ctx, w.path, string(w.buf[:end]), "append"); err != nil {
+		w.err = err
+		return err
+	}
+	w.written += int64(end)
+	w.buf = append(w.buf[:0], w.buf[end:]...)
+	w.options.progress(w.written, -1)
+	return nil
+}
+
+// lastRuneBoundary returns the length of the longest prefix of b that does not end in the
+// middle of a UTF-8 sequence. Invalid sequences are not held back.
+func lastRuneBoundary(b []byte) int {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if !utf8.RuneStart(b[i]) {
+			continue
+		}
+		if !utf8.FullRune(b[i:]) {
+			return i
+		}
+		break
+	}
+	return len(b)
+}
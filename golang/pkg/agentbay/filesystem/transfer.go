@@ -0,0 +1,347 @@
+package filesystem
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+)
+
+// DefaultTransferConcurrency is the number of chunks transferred in parallel by default
+const DefaultTransferConcurrency = 4
+
+// ProgressReporter receives progress updates from ReadFileParallel and WriteFileParallel.
+// Report is called after each completed chunk, never concurrently.
+type ProgressReporter interface {
+	Report(transferred, total int64)
+}
+
+// Report calls f, so that a ProgressFunc can be used as a ProgressReporter
+func (f ProgressFunc) Report(transferred, total int64) {
+	f(transferred, total)
+}
+
+// TransferOptions configures ReadFileParallel and WriteFileParallel
+type TransferOptions struct {
+	// ChunkSize is the number of bytes transferred per call. Defaults to ChunkSize. Sizes below
+	// utf8.UTFMax are raised to it, so that a chunk always holds at least one rune.
+	ChunkSize int
+	// Concurrency is the number of chunks transferred in parallel. Defaults to
+	// DefaultTransferConcurrency.
+	Concurrency int
+	// Progress receives progress updates, if set
+	Progress ProgressReporter
+	// Resume continues the transfer described by the checkpoint of a TransferError instead of
+	// starting over. Chunks that were already transferred are skipped.
+	Resume *TransferCheckpoint
+}
+
+func (o *TransferOptions) chunkSize() int {
+	if o == nil || o.ChunkSize <= 0 {
+		return ChunkSize
+	}
+	return max(o.ChunkSize, utf8.UTFMax)
+}
+
+func (o *TransferOptions) concurrency() int {
+	if o == nil || o.Concurrency <= 0 {
+		return DefaultTransferConcurrency
+	}
+	return o.Concurrency
+}
+
+func (o *TransferOptions) resume() *TransferCheckpoint {
+	if o == nil {
+		return nil
+	}
+	return o.Resume
+}
+
+func (o *TransferOptions) progress() ProgressReporter {
+	if o == nil {
+		return nil
+	}
+	return o.Progress
+}
+
+// TransferCheckpoint records which chunks of a parallel transfer have completed, so that the
+// transfer can be resumed with TransferOptions.Resume
+type TransferCheckpoint struct {
+	// Path is the path of the file in the session
+	Path string
+	// Size is the total size of the transfer in bytes
+	Size int64
+	// ChunkSize is the chunk size the transfer was started with
+	ChunkSize int
+	// Completed reports for each chunk whether it has been transferred
+	Completed []bool
+
+	write   bool
+	partDir string
+	chunks  []string
+}
+
+// Offset returns the number of bytes from the start of the file that have been transferred
+// without gaps
+func (c *TransferCheckpoint) Offset() int64 {
+	var offset int64
+	for index, done := range c.Completed {
+		if !done {
+			break
+		}
+		offset += int64(c.chunkLength(index))
+	}
+	return offset
+}
+
+// TransferError is returned when a parallel transfer fails part way. Checkpoint can be passed as
+// TransferOptions.Resume to transfer only the missing chunks.
+type TransferError struct {
+	Op         string // Operation that failed, e.g. "read file"
+	Path       string // Path of the file in the session
+	Checkpoint *TransferCheckpoint
+	Err        error // Error of the first chunk that failed
+}
+
+// Error implements the error interface
+func (e *TransferError) Error() string {
+	return fmt.Sprintf("%s %s: transfer interrupted at offset %d: %v", e.Op, e.Path, e.Checkpoint.Offset(), e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *TransferError) Unwrap() error {
+	return e.Err
+}
+
+// ReadFileParallel reads a file like ReadFile, requesting up to Concurrency chunks at the same
+// time. If a chunk cannot be read, the chunks that were read are kept in the checkpoint of the
+// returned *TransferError. options may be nil.
+func (fs *FileSystem) ReadFileParallel(ctx context.Context, path string, options *TransferOptions) (*FileReadResult, error) {
+	checkpoint := options.resume()
+	if checkpoint != nil {
+		if checkpoint.write || checkpoint.Path != path {
+			return nil, fileError("read file", path, errors.New("checkpoint belongs to a different transfer"))
+		}
+	} else {
+		fileInfoResult, err := fs.GetFileInfoWithContext(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		size := fileInfoResult.FileInfo.Size
+		chunkSize := options.chunkSize()
+		count := int((size + int64(chunkSize) - 1) / int64(chunkSize))
+		checkpoint = &TransferCheckpoint{
+			Path:      path,
+			Size:      size,
+			ChunkSize: chunkSize,
+			Completed: make([]bool, count),
+			chunks:    make([]string, count),
+		}
+	}
+
+	fs.logger().Debug("ReadFileParallel: starting read", "path", path, "size", checkpoint.Size,
+		"chunks", len(checkpoint.Completed), "concurrency", options.concurrency())
+
+	requestID, err := runTransfer(ctx, checkpoint, options, func(ctx context.Context, index int) (string, error) {
+		offset := int64(index) * int64(checkpoint.ChunkSize)
+		result, err := fs.readFileChunk(ctx, path, int(offset), checkpoint.chunkLength(index))
+		if err != nil {
+			return "", err
+		}
+		checkpoint.chunks[index] = result.Content
+		return result.RequestID, nil
+	})
+	if err != nil {
+		return nil, &TransferError{Op: "read file", Path: path, Checkpoint: checkpoint, Err: err}
+	}
+
+	return &FileReadResult{
+		ApiResponse: models.ApiResponse{
+			RequestID: requestID,
+		},
+		Content: strings.Join(checkpoint.chunks, ""),
+	}, nil
+}
+
+// WriteFileParallel writes content to a file, replacing it, by uploading up to Concurrency
+// chunks at the same time into part files next to path and joining them in order once all have
+// been uploaded. Chunks are split at UTF-8 character boundaries. If a chunk cannot be written,
+// the part files are kept so that the transfer can be resumed from the checkpoint of the
+// returned *TransferError. options may be nil.
+func (fs *FileSystem) WriteFileParallel(ctx context.Context, path, content string, options *TransferOptions) (*FileWriteResult, error) {
+	checkpoint := options.resume()
+	if checkpoint != nil {
+		chunks := splitChunks(content, checkpoint.ChunkSize)
+		if !checkpoint.write || checkpoint.Path != path || checkpoint.Size != int64(len(content)) ||
+			len(chunks) != len(checkpoint.Completed) {
+			return nil, fileError("write file", path, errors.New("checkpoint belongs to a different transfer"))
+		}
+		checkpoint.chunks = chunks
+	} else {
+		chunkSize := options.chunkSize()
+		chunks := splitChunks(content, chunkSize)
+		if len(chunks) <= 1 {
+			return fs.writeFileChunk(ctx, path, content, "overwrite")
+		}
+		suffix := make([]byte, 8)
+		if _, err := rand.Read(suffix); err != nil {
+			return nil, fileError("write file", path, fmt.Errorf("failed to generate part file names: %w", err))
+		}
+		checkpoint = &TransferCheckpoint{
+			Path:      path,
+			Size:      int64(len(content)),
+			ChunkSize: chunkSize,
+			Completed: make([]bool, len(chunks)),
+			write:     true,
+			partDir:   path + ".agentbay-" + hex.EncodeToString(suffix) + ".parts",
+			chunks:    chunks,
+		}
+		if _, err := fs.shell(ctx, "write file", path, "mkdir -p "+shellQuote(checkpoint.partDir)); err != nil {
+			return nil, err
+		}
+	}
+
+	fs.logger().Debug("WriteFileParallel: starting write", "path", path, "size", checkpoint.Size,
+		"chunks", len(checkpoint.Completed), "concurrency", options.concurrency())
+
+	_, err := runTransfer(ctx, checkpoint, options, func(ctx context.Context, index int) (string, error) {
+		part := checkpoint.partDir + "/" + strconv.Itoa(index)
+		result, err := fs.writeFileChunk(ctx, part, checkpoint.chunks[index], "overwrite")
+		if err != nil {
+			return "", err
+		}
+		return result.RequestID, nil
+	})
+	if err != nil {
+		return nil, &TransferError{Op: "write file", Path: path, Checkpoint: checkpoint, Err: err}
+	}
+
+	// The parts are joined into a temporary file that replaces path, so path is never left
+	// half written
+	dir, target := shellQuote(checkpoint.partDir), shellQuote(path)
+	script := "i=0; : > " + dir + "/joined || exit 1; " +
+		"while [ $i -lt " + strconv.Itoa(len(checkpoint.Completed)) + " ]; do " +
+		"cat " + dir + "/$i >> " + dir + "/joined || exit 1; i=$((i+1)); done; " +
+		"mv -f " + dir + "/joined " + target + " && rm -rf " + dir + " && echo joined"
+	result, err := fs.shell(ctx, "write file", path, script)
+	if err == nil && strings.TrimSpace(result.Data) != "joined" {
+		err = fileError("write file", path, fmt.Errorf("failed to join part files: %s", strings.TrimSpace(result.Data)))
+	}
+	if err != nil {
+		return nil, &TransferError{Op: "write file", Path: path, Checkpoint: checkpoint, Err: err}
+	}
+
+	return &FileWriteResult{
+		ApiResponse: models.ApiResponse{
+			RequestID: result.RequestID,
+		},
+		Success: true,
+	}, nil
+}
+
+// chunkLength returns the length in bytes of the chunk with the given index
+func (c *TransferCheckpoint) chunkLength(index int) int {
+	if c.write {
+		return len(c.chunks[index])
+	}
+	offset := int64(index) * int64(c.ChunkSize)
+	if remaining := c.Size - offset; remaining < int64(c.ChunkSize) {
+		return int(remaining)
+	}
+	return c.ChunkSize
+}
+
+// runTransfer calls transfer for every chunk of checkpoint that has not completed yet, using up
+// to Concurrency goroutines, and returns the request ID of the last chunk. After the first
+// failure no new chunks are started.
+func runTransfer(ctx context.Context, checkpoint *TransferCheckpoint, options *TransferOptions,
+	transfer func(ctx context.Context, index int) (string, error)) (string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var transferred int64
+	pending := make(chan int, len(checkpoint.Completed))
+	for index, done := range checkpoint.Completed {
+		if done {
+			transferred += int64(checkpoint.chunkLength(index))
+		} else {
+			pending <- index
+		}
+	}
+	close(pending)
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		firstErr  error
+		requestID string
+	)
+	progress := options.progress()
+	for worker := 0; worker < options.concurrency(); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range pending {
+				if ctx.Err() != nil {
+					return
+				}
+				id, err := transfer(ctx, index)
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = fmt.Errorf("chunk %d: %w", index, err)
+						cancel()
+					}
+				} else {
+					checkpoint.Completed[index] = true
+					transferred += int64(checkpoint.chunkLength(index))
+					requestID = id
+					if progress != nil {
+						progress.Report(transferred, checkpoint.Size)
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr == nil && ctx.Err() != nil {
+		// The parent context was cancelled before all chunks were started
+		for _, done := range checkpoint.Completed {
+			if !done {
+				return requestID, ctx.Err()
+			}
+		}
+	}
+	return requestID, firstErr
+}
+
+// splitChunks splits content into chunks of at most chunkSize bytes without splitting UTF-8
+// sequences
+func splitChunks(content string, chunkSize int) []string {
+	var chunks []string
+	for len(content) > 0 {
+		end := len(content)
+		if end > chunkSize {
+			end = chunkSize
+			for i := end; i > 0 && i > chunkSize-utf8.UTFMax; i-- {
+				if utf8.RuneStart(content[i]) {
+					end = i
+					break
+				}
+			}
+		}
+		chunks = append(chunks, content[:end])
+		content = content[end:]
+	}
+	return chunks
+}
@@ -0,0 +1,304 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	iofs "io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+)
+
+// findFormat is the find -printf format parsed by parseFindOutput: type, octal permissions,
+// size, modification time, owner, group and path
+const findFormat = `%y\t%m\t%s\t%T@\t%u\t%g\t%p\n`
+
+// WalkFunc is called by Walk for each file or directory, like fs.WalkDirFunc. If reading the
+// root fails, it is called once with info set to nil and err describing the failure. Returning
+// fs.SkipDir skips the directory, or the remaining entries of the containing directory when
+// returned for a file; returning fs.SkipAll stops the walk. Any other error stops the walk and
+// is returned by Walk.
+type WalkFunc func(path string, info *FileInfo, err error) error
+
+// GlobResult wraps the files matched by Glob and RequestID
+type GlobResult struct {
+	models.ApiResponse
+	Matches []*FileInfo
+}
+
+// FileMode returns the mode and type bits of the file as an fs.FileMode
+func (f *FileInfo) FileMode() iofs.FileMode {
+	mode, _ := parseFileMode(f.Mode)
+	return mode
+}
+
+// ModTimeValue returns the modification time of the file, or the zero time if it is unknown
+func (f *FileInfo) ModTimeValue() time.Time {
+	modTime, err := time.Parse(time.RFC3339Nano, f.ModTime)
+	if err != nil {
+		return time.Time{}
+	}
+	return modTime
+}
+
+// Walk walks the file tree rooted at root in the session, calling fn for each file or
+// directory including root, in lexical order like filepath.WalkDir. Symbolic links are
+// reported with IsSymlink set and are not followed. The whole tree is listed with a single call
+// to the shell tool before fn is first called.
+func (fs *FileSystem) Walk(root string, fn WalkFunc) error {
+	return fs.WalkWithContext(context.Background(), root, fn)
+}
+
+// WalkWithContext walks the file tree rooted at root like Walk, honouring the cancellation and
+// deadline of ctx.
+func (fs *FileSystem) WalkWithContext(ctx context.Context, root string, fn WalkFunc) error {
+	root = cleanRemotePath(root)
+	infos, _, err := fs.find(ctx, "walk", root, "")
+	if err == nil && len(infos) == 0 {
+		err = fileError("walk", root, os.ErrNotExist)
+	}
+	if err != nil {
+		err = fn(root, nil, err)
+		if errors.Is(err, iofs.SkipDir) || errors.Is(err, iofs.SkipAll) {
+			return nil
+		}
+		return err
+	}
+
+	children := map[string][]*FileInfo{}
+	for _, info := range infos[1:] {
+		dir := path.Dir(info.Path)
+		children[dir] = append(children[dir], info)
+	}
+	for _, entries := range children {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	}
+
+	err = walkTree(infos[0], children, fn)
+	if errors.Is(err, iofs.SkipDir) || errors.Is(err, iofs.SkipAll) {
+		return nil
+	}
+	return err
+}
+
+// walkTree calls fn for info and, if it is a directory, for its children
+func walkTree(info *FileInfo, children map[string][]*FileInfo, fn WalkFunc) error {
+	if err := fn(info.Path, info, nil); err != nil || !info.IsDirectory {
+		return err
+	}
+	for _, child := range children[info.Path] {
+		if err := walkTree(child, children, fn); err != nil {
+			if errors.Is(err, iofs.SkipDir) && !child.IsDirectory {
+				// Skip the remaining entries of this directory
+				return nil
+			}
+			if errors.Is(err, iofs.SkipDir) {
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Glob returns the files and directories in the session matching pattern, in lexical order.
+// The pattern syntax is that of path.Match, applied to each path element like filepath.Glob;
+// the only possible error besides a failing tool call is path.ErrBadPattern.
+func (fs *FileSystem) Glob(pattern string) (*GlobResult, error) {
+	return fs.GlobWithContext(context.Background(), pattern)
+}
+
+// GlobWithContext returns the files matching pattern like Glob, honouring the cancellation and
+// deadline of ctx.
+func (fs *FileSystem) GlobWithContext(ctx context.Context, pattern string) (*GlobResult, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fileError("glob", pattern, err)
+	}
+
+	// Only the part of the tree below the longest prefix without meta characters is listed
+	pattern = cleanRemotePath(pattern)
+	base, depth := globBase(pattern)
+	depthArgs := "-mindepth " + strconv.Itoa(depth) + " -maxdepth " + strconv.Itoa(depth)
+	infos, requestID, err := fs.find(ctx, "glob", base, depthArgs)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &GlobResult{ApiResponse: models.ApiResponse{RequestID: requestID}}, nil
+		}
+		return nil, err
+	}
+
+	var matches []*FileInfo
+	for _, info := range infos {
+		if ok, _ := path.Match(pattern, info.Path); ok {
+			matches = append(matches, info)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Path < matches[j].Path })
+
+	return &GlobResult{
+		ApiResponse: models.ApiResponse{
+			RequestID: requestID,
+		},
+		Matches: matches,
+	}, nil
+}
+
+// globBase splits pattern into the directory to list and the depth of the entries below it
+// that can match
+func globBase(pattern string) (string, int) {
+	elems := strings.Split(pattern, "/")
+	for i, elem := range elems {
+		if strings.ContainsAny(elem, `*?[\`) {
+			base := strings.Join(elems[:i], "/")
+			switch {
+			case i == 0:
+				base = "."
+			case base == "":
+				base = "/"
+			}
+			return base, len(elems) - i
+		}
+	}
+	return pattern, 0
+}
+
+// find lists root and, unless limited by args, the tree below it with the shell tool. Entries
+// are returned in the order find reports them, root first. It returns an error wrapping
+// os.ErrNotExist if root does not exist.
+func (fs *FileSystem) find(ctx context.Context, op, root, args string) ([]*FileInfo, string, error) {
+	script := "[ -e " + shellQuote(root) + " ] || [ -L " + shellQuote(root) + " ] || { echo missing; exit 0; }; " +
+		"find " + shellQuote(root) + " " + args + " -printf '" + findFormat + "' 2>/dev/null"
+	result, err := fs.shell(ctx, op, root, script)
+	if err != nil {
+		return nil, "", err
+	}
+	if strings.TrimSpace(result.Data) == "missing" {
+		return nil, result.RequestID, fileError(op, root, os.ErrNotExist)
+	}
+	return parseFindOutput(result.Data, root), result.RequestID, nil
+}
+
+// parseFindOutput parses lines printed with findFormat. Paths below "." are reported without
+// the leading "./", like filepath.Glob does for relative patterns.
+func parseFindOutput(data, root string) []*FileInfo {
+	var infos []*FileInfo
+	for _, line := range strings.Split(data, "\n") {
+		parts := strings.SplitN(line, "\t", 7)
+		if len(parts) < 7 {
+			continue
+		}
+		perm, err := strconv.ParseUint(parts[1], 8, 32)
+		if err != nil {
+			continue
+		}
+		size, _ := strconv.ParseInt(parts[2], 10, 64)
+		modTime := parseUnixTime(parts[3])
+
+		mode := iofs.FileMode(perm)&iofs.ModePerm | unixSpecialBits(uint32(perm))
+		switch parts[0] {
+		case "d":
+			mode |= iofs.ModeDir
+		case "l":
+			mode |= iofs.ModeSymlink
+		case "p":
+			mode |= iofs.ModeNamedPipe
+		case "s":
+			mode |= iofs.ModeSocket
+		case "c":
+			mode |= iofs.ModeDevice | iofs.ModeCharDevice
+		case "b":
+			mode |= iofs.ModeDevice
+		}
+
+		p := parts[6]
+		if root == "." && strings.HasPrefix(p, "./") {
+			p = p[2:]
+		}
+		infos = append(infos, &FileInfo{
+			Name:        path.Base(p),
+			Path:        p,
+			Size:        size,
+			IsDirectory: mode.IsDir(),
+			IsSymlink:   mode&iofs.ModeSymlink != 0,
+			ModTime:     modTime.UTC().Format(time.RFC3339Nano),
+			Mode:        mode.String(),
+			Owner:       parts[4],
+			Group:       parts[5],
+		})
+	}
+	return infos
+}
+
+// parseUnixTime parses a Unix time in seconds with an optional fraction, as printed by %T@
+func parseUnixTime(s string) time.Time {
+	secText, fracText, _ := strings.Cut(s, ".")
+	sec, err := strconv.ParseInt(secText, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	fracText = (fracText + "000000000")[:9]
+	nsec, _ := strconv.ParseInt(fracText, 10, 64)
+	return time.Unix(sec, nsec)
+}
+
+// unixSpecialBits converts the setuid, setgid and sticky bits of a Unix mode to fs.FileMode bits
+func unixSpecialBits(perm uint32) iofs.FileMode {
+	var mode iofs.FileMode
+	if perm&0o4000 != 0 {
+		mode |= iofs.ModeSetuid
+	}
+	if perm&0o2000 != 0 {
+		mode |= iofs.ModeSetgid
+	}
+	if perm&0o1000 != 0 {
+		mode |= iofs.ModeSticky
+	}
+	return mode
+}
+
+// parseFileMode parses the string form of an fs.FileMode, as produced by FileMode.String, or
+// an octal permission string such as "0644"
+func parseFileMode(s string) (iofs.FileMode, bool) {
+	if perm, err := strconv.ParseUint(s, 8, 32); err == nil {
+		return iofs.FileMode(perm)&iofs.ModePerm | unixSpecialBits(uint32(perm)), true
+	}
+	if len(s) < 10 {
+		return 0, false
+	}
+	typeChars, permChars := s[:len(s)-9], s[len(s)-9:]
+
+	var mode iofs.FileMode
+	const typeLetters = "dalTLDpSugct?"
+	for _, c := range typeChars {
+		index := strings.IndexRune(typeLetters, c)
+		if index < 0 {
+			if c == '-' {
+				continue
+			}
+			return 0, false
+		}
+		mode |= 1 << uint(32-1-index)
+	}
+	const permLetters = "rwxrwxrwx"
+	for i, c := range permChars {
+		if c == rune(permLetters[i]) {
+			mode |= 1 << uint(8-i)
+		} else if c != '-' {
+			return 0, false
+		}
+	}
+	return mode, true
+}
+
+// cleanRemotePath cleans p like path.Clean, keeping an empty path empty
+func cleanRemotePath(p string) string {
+	if p == "" {
+		return p
+	}
+	return path.Clean(p)
+}
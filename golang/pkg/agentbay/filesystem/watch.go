@@ -0,0 +1,359 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/logger"
+)
+
+// DefaultWatchInterval is the polling interval used when WatchOptions.Interval is not set
+const DefaultWatchInterval = 500 * time.Millisecond
+
+// DefaultWatchMaxBackoff is the longest delay between polls after repeated errors
+const DefaultWatchMaxBackoff = 30 * time.Second
+
+// watchBufferSize is the capacity of the Events and Errors channels of a Watcher
+const watchBufferSize = 64
+
+// WatchOptions configures a Watcher
+type WatchOptions struct {
+	// Interval is the delay between polls. Defaults to DefaultWatchInterval.
+	Interval time.Duration
+	// Debounce is the quiet period after which the events collected so far are delivered.
+	// Events for the same path within the period are coalesced into one, e.g. a create
+	// followed by modifications yields a single create and a create followed by a delete yields
+	// nothing. If zero, the events of each poll are delivered as they arrive.
+	Debounce time.Duration
+	// MaxDelay bounds how long events are held back by Debounce while changes keep arriving.
+	// Defaults to four times Debounce.
+	MaxDelay time.Duration
+	// Recursive delivers events for the whole tree below the directory. If false, only events
+	// for direct children of the directory are delivered.
+	Recursive bool
+	// Include lists glob patterns, in path.Match syntax, of the paths to deliver events for.
+	// A pattern matches the path relative to the directory, its base name or one of its parent
+	// directories. If empty, all paths are included.
+	Include []string
+	// Exclude lists glob patterns of paths to drop events for. Exclude takes precedence over
+	// Include.
+	Exclude []string
+	// MaxBackoff is the longest delay between polls when polling fails repeatedly; the delay
+	// doubles with each consecutive failure. Defaults to DefaultWatchMaxBackoff.
+	MaxBackoff time.Duration
+}
+
+func (o *WatchOptions) interval() time.Duration {
+	if o == nil || o.Interval <= 0 {
+		return DefaultWatchInterval
+	}
+	return o.Interval
+}
+
+func (o *WatchOptions) debounce() time.Duration {
+	if o == nil || o.Debounce < 0 {
+		return 0
+	}
+	return o.Debounce
+}
+
+func (o *WatchOptions) maxDelay() time.Duration {
+	if o == nil || o.MaxDelay <= 0 {
+		return 4 * o.debounce()
+	}
+	return o.MaxDelay
+}
+
+func (o *WatchOptions) maxBackoff() time.Duration {
+	if o == nil || o.MaxBackoff <= 0 {
+		return DefaultWatchMaxBackoff
+	}
+	return o.MaxBackoff
+}
+
+// backoff returns the delay before the next poll after failures consecutive errors
+func (o *WatchOptions) backoff(failures int) time.Duration {
+	delay := o.interval()
+	for i := 0; i < failures && delay < o.maxBackoff(); i++ {
+		delay *= 2
+	}
+	if delay > o.maxBackoff() {
+		delay = o.maxBackoff()
+	}
+	return delay
+}
+
+// matches reports whether an event for path below root passes the filters
+func (o *WatchOptions) matches(root, eventPath string) bool {
+	rel := strings.TrimPrefix(strings.TrimPrefix(eventPath, strings.TrimSuffix(root, "/")), "/")
+	if rel == "" {
+		rel = path.Base(eventPath)
+	}
+	if o == nil {
+		return !strings.Contains(rel, "/")
+	}
+	if !o.Recursive && strings.Contains(rel, "/") {
+		return false
+	}
+	if len(o.Exclude) > 0 && matchAny(o.Exclude, rel) {
+		return false
+	}
+	return len(o.Include) == 0 || matchAny(o.Include, rel)
+}
+
+// Watcher delivers the file changes in a directory of the session. It polls the
+// get_file_change tool in a single goroutine until Close is called or the context it was
+// started with is done, after which the Events and Errors channels are closed.
+type Watcher struct {
+	fs      *FileSystem
+	path    string
+	options *WatchOptions
+	events  chan FileChangeEvent
+	errors  chan error
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// Watch starts watching the directory at path for file changes. options may be nil, which
+// watches the direct children of the directory at the default interval.
+func (fs *FileSystem) Watch(ctx context.Context, path string, options *WatchOptions) (*Watcher, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, fileError("watch directory", path, errors.New("path must not be empty"))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	w := &Watcher{
+		fs:      fs,
+		path:    path,
+		options: options,
+		events:  make(chan FileChangeEvent, watchBufferSize),
+		errors:  make(chan error, watchBufferSize),
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	go func() {
+		defer close(w.done)
+		defer close(w.errors)
+		defer close(w.events)
+		fs.watch(ctx, path, options, w.deliver, w.report)
+	}()
+	return w, nil
+}
+
+// Events returns the channel on which file changes are delivered. Polling pauses while the
+// channel is full.
+func (w *Watcher) Events() <-chan FileChangeEvent {
+	return w.events
+}
+
+// Errors returns the channel on which polling errors are delivered. Errors are dropped while
+// the channel is full.
+func (w *Watcher) Errors() <-chan error {
+	return w.errors
+}
+
+// Done returns a channel that is closed once the watcher has stopped
+func (w *Watcher) Done() <-chan struct{} {
+	return w.done
+}
+
+// Close stops the watcher and waits until its goroutine has exited. It is safe to call Close
+// more than once.
+func (w *Watcher) Close() error {
+	w.cancel()
+	<-w.done
+	return nil
+}
+
+// deliver sends events to the Events channel, returning false if ctx is done first
+func (w *Watcher) deliver(ctx context.Context, events []*FileChangeEvent) bool {
+	for _, event := range events {
+		select {
+		case w.events <- *event:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// report sends err to the Errors channel unless it is full
+func (w *Watcher) report(err error) {
+	select {
+	case w.errors <- err:
+	default:
+		w.fs.logger().Debug("Dropped directory watch error", "path", w.path, logger.KeyError, err)
+	}
+}
+
+// watch polls path for file changes until ctx is done, passing filtered and, if configured,
+// debounced events to deliver and polling errors to report. deliver returns false to stop.
+func (fs *FileSystem) watch(ctx context.Context, path string, options *WatchOptions,
+	deliver func(ctx context.Context, events []*FileChangeEvent) bool, report func(err error)) {
+	log := fs.logger()
+	log.Info("Starting directory monitoring", "path", path, "interval", options.interval())
+	defer log.Info("Stopped monitoring directory", "path", path)
+
+	poll := time.NewTimer(options.interval())
+	defer poll.Stop()
+
+	var (
+		pending  coalescer
+		quiet    <-chan time.Time
+		deadline <-chan time.Time
+		failures int
+	)
+	flush := func() bool {
+		events := pending.take()
+		quiet, deadline = nil, nil
+		return len(events) == 0 || deliver(ctx, events)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-quiet:
+			if !flush() {
+				return
+			}
+		case <-deadline:
+			if !flush() {
+				return
+			}
+		case <-poll.C:
+			result, err := fs.GetFileChangeWithContext(ctx, path)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				failures++
+				log.Warn("Error monitoring directory", "path", path, "failures", failures, logger.KeyError, err)
+				report(err)
+				poll.Reset(options.backoff(failures))
+				continue
+			}
+			failures = 0
+
+			var events []*FileChangeEvent
+			for _, event := range result.Events {
+				if options.matches(path, event.Path) {
+					events = append(events, event)
+				}
+			}
+			if len(events) > 0 {
+				log.Debug("Detected file changes", "path", path, "count", len(events))
+				if options.debounce() == 0 {
+					if !deliver(ctx, events) {
+						return
+					}
+				} else {
+					if deadline == nil {
+						deadline = time.After(options.maxDelay())
+					}
+					pending.add(events)
+					quiet = time.After(options.debounce())
+				}
+			}
+			poll.Reset(options.interval())
+		}
+	}
+}
+
+// coalescer merges bursts of events into at most one event per path, in the order in which
+// the paths first changed
+type coalescer struct {
+	order  []string
+	byPath map[string]*FileChangeEvent
+}
+
+// add merges events into the pending events
+func (c *coalescer) add(events []*FileChangeEvent) {
+	if c.byPath == nil {
+		c.byPath = map[string]*FileChangeEvent{}
+	}
+	for _, event := range events {
+		prev, ok := c.byPath[event.Path]
+		if !ok {
+			merged := *event
+			c.byPath[event.Path] = &merged
+			c.order = append(c.order, event.Path)
+			continue
+		}
+		switch {
+		case prev.EventType == "create" && event.EventType == "delete":
+			// The path never existed as far as the receiver is concerned
+			delete(c.byPath, event.Path)
+		case prev.EventType == "create":
+			prev.PathType = event.PathType
+		case prev.EventType == "delete" && event.EventType == "create":
+			prev.EventType, prev.PathType = "modify", event.PathType
+		default:
+			*prev = *event
+		}
+	}
+}
+
+// take returns the pending events and resets the coalescer
+func (c *coalescer) take() []*FileChangeEvent {
+	var events []*FileChangeEvent
+	seen := make(map[string]bool, len(c.order))
+	for _, p := range c.order {
+		if event, ok := c.byPath[p]; ok && !seen[p] {
+			seen[p] = true
+			events = append(events, event)
+		}
+	}
+	c.order, c.byPath = nil, nil
+	return events
+}
+
+// runWatchCallback calls callback with events, recovering from panics
+func (fs *FileSystem) runWatchCallback(path string, callback func([]*FileChangeEvent), events []*FileChangeEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			fs.logger().Error("Panic in directory watch callback", "path", path, logger.KeyError, fmt.Sprint(r))
+		}
+	}()
+	callback(events)
+}
+
+// watchDirectory implements WatchDirectory on top of watch. Callbacks run one at a time on
+// a single goroutine.
+func (fs *FileSystem) watchDirectory(path string, callback func([]*FileChangeEvent), interval time.Duration,
+	stopCh <-chan struct{}) *sync.WaitGroup {
+	ctx, cancel := context.WithCancel(context.Background())
+	batches := make(chan []*FileChangeEvent, 1)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		defer close(batches)
+		fs.watch(ctx, path, &WatchOptions{Interval: interval, Recursive: true},
+			func(ctx context.Context, events []*FileChangeEvent) bool {
+				select {
+				case batches <- events:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			}, func(error) {})
+	}()
+	go func() {
+		defer wg.Done()
+		for events := range batches {
+			fs.runWatchCallback(path, callback, events)
+		}
+	}()
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+	return &wg
+}
@@ -0,0 +1,47 @@
+package agentbay
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	mcp "github.com/aliyun/wuying-agentbay-sdk/golang/api/client"
+)
+
+// NewHTTPClient returns an HTTP client for the OpenAPI client whose requests time out after
+// timeoutMs milliseconds. The default HTTP client of dara is shared by all OpenAPI clients of an
+// endpoint and has its timeout reset by every request, which races with the requests in flight;
+// this one is configured once and is safe for concurrent use. NewAgentBay sets it in the
+// configuration of its client. Set it in the HttpClient field of the configuration when
+// assigning a client created otherwise to AgentBay.Client.
+func NewHTTPClient(timeoutMs int) dara.HttpClient {
+	return &apiHTTPClient{timeout: time.Duration(timeoutMs) * time.Millisecond}
+}
+
+// apiHTTPClient is the HTTP client returned by NewHTTPClient
+type apiHTTPClient struct {
+	timeout time.Duration
+	once    sync.Once
+	client  *http.Client
+}
+
+// Call implements dara.HttpClient. Like the default client of dara, it keeps the transport of the
+// first request, which dara builds from the same client configuration for every request, so that
+// connections are reused.
+func (c *apiHTTPClient) Call(request *http.Request, transport *http.Transport) (*http.Response, error) {
+	c.once.Do(func() {
+		c.client = &http.Client{Transport: transport, Timeout: c.timeout}
+	})
+	return c.client.Do(request)
+}
+
+// apiClient returns a copy of the OpenAPI client for a single call. The OpenAPI client takes its
+// debug headers by clearing them on every request, which races when concurrent calls share it.
+func (a *AgentBay) apiClient() *mcp.Client {
+	if a.Client == nil {
+		return nil
+	}
+	client := *a.Client
+	return &client
+}
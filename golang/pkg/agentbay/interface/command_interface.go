@@ -1,6 +1,8 @@
 package interfaces
 
 import (
+	"context"
+
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/command"
 )
 
@@ -10,4 +12,10 @@ import (
 type CommandInterface interface {
 	// ExecuteCommand executes a command with optional timeout
 	ExecuteCommand(command string, timeoutMs ...int) (*command.CommandResult, error)
+	// Run executes a command and returns its exit code, stdout and stderr
+	Run(ctx context.Context, options command.CommandOptions) (*command.CommandResult, error)
+	// Stream starts a command in the background and delivers its output incrementally
+	Stream(ctx context.Context, options command.CommandOptions) (*command.CommandStream, error)
+	// Start starts a command in the background and returns a handle to manage it
+	Start(ctx context.Context, options command.CommandOptions) (*command.Process, error)
 }
@@ -1,6 +1,8 @@
 package interfaces
 
 import (
+	"io"
+	"os"
 	"sync"
 	"time"
 
@@ -17,6 +19,18 @@ type FileSystemInterface interface {
 	// WriteFile writes content to a file. Automatically handles large files by chunking.
 	WriteFile(path, content string, mode string) (*filesystem.FileWriteResult, error)
 
+	// ReadFileBytes reads the raw contents of a file and verifies their checksum. Safe for binary files.
+	ReadFileBytes(path string) (*filesystem.FileReadBytesResult, error)
+
+	// WriteFileBytes writes raw data to a file and verifies its checksum. Safe for binary data.
+	WriteFileBytes(path string, data []byte) (*filesystem.FileWriteResult, error)
+
+	// Open opens a file for streamed reading
+	Open(path string) (io.ReadCloser, error)
+
+	// Create creates or truncates a file and returns a writer that streams data to it
+	Create(path string) (io.WriteCloser, error)
+
 	// EditFile edits a file by replacing occurrences of oldText with newText
 	EditFile(path string, edits []map[string]string, dryRun bool) (*filesystem.FileWriteResult, error)
 
@@ -32,6 +46,27 @@ type FileSystemInterface interface {
 	// MoveFile moves a file or directory from source to destination
 	MoveFile(source, destination string) (*filesystem.FileWriteResult, error)
 
+	// Remove removes a file or empty directory, or a whole tree if recursive is set
+	Remove(path string, recursive bool) (*filesystem.FileWriteResult, error)
+
+	// Copy copies a file, or a whole tree if recursive is set
+	Copy(src, dst string, recursive bool) (*filesystem.FileWriteResult, error)
+
+	// Chmod changes the permissions of a file
+	Chmod(path string, mode os.FileMode) (*filesystem.FileWriteResult, error)
+
+	// Symlink creates link as a symbolic link to target
+	Symlink(target, link string) (*filesystem.FileWriteResult, error)
+
+	// Stat gets information about a file without following symbolic links
+	Stat(path string) (*filesystem.FileInfoResult, error)
+
+	// Pack creates an archive in the given format containing paths
+	Pack(paths []string, archivePath string, format filesystem.ArchiveFormat) (*filesystem.FileWriteResult, error)
+
+	// Unpack extracts an archive into dest according to policy
+	Unpack(archivePath, dest string, policy *filesystem.ExtractPolicy) (*filesystem.FileWriteResult, error)
+
 	// ReadMultipleFiles reads the contents of multiple files
 	ReadMultipleFiles(paths []string) (map[string]string, error)
 
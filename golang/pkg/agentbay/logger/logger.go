@@ -0,0 +1,158 @@
+// Package logger provides the pluggable, structured logging used throughout the AgentBay SDK.
+//
+// The SDK never writes to stdout directly. Every message goes through a Logger, which can be
+// supplied with agentbay.WithLogger. By default messages are sent to slog.Default(), with API
+// request and response payloads logged at debug level and sensitive values redacted.
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/utils"
+)
+
+// Standard field keys attached to SDK log records
+const (
+	// KeyAPI is the name of the AgentBay API or MCP tool being called
+	KeyAPI = "api"
+	// KeySessionID is the ID of the session the record relates to
+	KeySessionID = "session_id"
+	// KeyRequestID is the request ID returned by the AgentBay API
+	KeyRequestID = "request_id"
+	// KeyDuration is the elapsed time of the operation
+	KeyDuration = "duration"
+	// KeyError is the (redacted) error of a failed operation
+	KeyError = "error"
+)
+
+// Logger is the logging interface used by the SDK.
+// Arguments after msg are alternating key/value pairs, as in log/slog.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// slogLogger adapts a *slog.Logger to Logger, redacting sensitive values
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger returns a Logger that writes to l.
+// If l is nil, slog.Default() is used at the time each record is written.
+// Messages, string values and errors are passed through utils.SanitizeString and
+// utils.SanitizeError so API keys never reach the log output.
+func NewSlogLogger(l *slog.Logger) Logger {
+	return &slogLogger{logger: l}
+}
+
+func (s *slogLogger) log(level slog.Level, msg string, args []any) {
+	l := s.logger
+	if l == nil {
+		l = slog.Default()
+	}
+	if !l.Enabled(context.Background(), level) {
+		return
+	}
+	l.Log(context.Background(), level, utils.SanitizeString(msg), redactArgs(args)...)
+}
+
+// Debug logs at debug level
+func (s *slogLogger) Debug(msg string, args ...any) { s.log(slog.LevelDebug, msg, args) }
+
+// Info logs at info level
+func (s *slogLogger) Info(msg string, args ...any) { s.log(slog.LevelInfo, msg, args) }
+
+// Warn logs at warn level
+func (s *slogLogger) Warn(msg string, args ...any) { s.log(slog.LevelWarn, msg, args) }
+
+// Error logs at error level
+func (s *slogLogger) Error(msg string, args ...any) { s.log(slog.LevelError, msg, args) }
+
+// redactArgs returns a copy of args with every string, error and fmt.Stringer value sanitized
+func redactArgs(args []any) []any {
+	if len(args) == 0 {
+		return args
+	}
+
+	redacted := make([]any, len(args))
+	for i, arg := range args {
+		redacted[i] = redactValue(arg)
+	}
+	return redacted
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return utils.SanitizeString(v)
+	case error:
+		return utils.SanitizeError(v)
+	case slog.Attr:
+		return slog.Any(v.Key, redactValue(v.Value.Any()))
+	case fmt.Stringer:
+		return utils.SanitizeString(v.String())
+	default:
+		return v
+	}
+}
+
+// nopLogger discards every record
+type nopLogger struct{}
+
+// NewNopLogger returns a Logger that discards all output
+func NewNopLogger() Logger {
+	return nopLogger{}
+}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Info(string, ...any)  {}
+func (nopLogger) Warn(string, ...any)  {}
+func (nopLogger) Error(string, ...any) {}
+
+// defaultLogger holds the process-wide fallback Logger
+var defaultLogger atomic.Value
+
+func init() {
+	defaultLogger.Store(loggerHolder{NewSlogLogger(nil)})
+}
+
+// loggerHolder keeps the concrete type stored in defaultLogger stable
+type loggerHolder struct {
+	Logger
+}
+
+// Default returns the Logger used by components that have not been given one explicitly
+func Default() Logger {
+	return defaultLogger.Load().(loggerHolder).Logger
+}
+
+// SetDefault replaces the process-wide fallback Logger. Passing nil restores the slog adapter.
+func SetDefault(l Logger) {
+	if l == nil {
+		l = NewSlogLogger(nil)
+	}
+	defaultLogger.Store(loggerHolder{l})
+}
+
+// Provider is implemented by SDK components that carry their own Logger, such as sessions
+type Provider interface {
+	GetLogger() Logger
+}
+
+// From returns the Logger of v if it implements Provider, and Default() otherwise.
+// Service packages use it to pick up the logger of the session they were created with.
+func From(v any) Logger {
+	if p, ok := v.(Provider); ok {
+		if l := p.GetLogger(); l != nil {
+			return l
+		}
+	}
+	return Default()
+}
@@ -0,0 +1,70 @@
+package agentbay
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/logger"
+)
+
+// WithLogger returns an Option that sets the logger used by the AgentBay client and its sessions.
+// Passing logger.NewNopLogger() silences the SDK entirely.
+func WithLogger(l logger.Logger) Option {
+	return func(c *AgentBayConfig) {
+		c.logger = l
+	}
+}
+
+// GetLogger returns the logger used by this client, falling back to logger.Default()
+func (a *AgentBay) GetLogger() logger.Logger {
+	if a == nil || a.logger == nil {
+		return logger.Default()
+	}
+	return a.logger
+}
+
+// SetLogger replaces the logger used by this client and its sessions.
+// Passing nil restores logger.Default().
+func (a *AgentBay) SetLogger(l logger.Logger) {
+	a.logger = l
+}
+
+// logAPIRequest logs an outgoing API call together with its request parameters
+func logAPIRequest(l logger.Logger, api, sessionID string, args ...any) {
+	fields := append([]any{logger.KeyAPI, api}, sessionField(sessionID)...)
+	l.Debug("API request", append(fields, args...)...)
+}
+
+// logAPIResponse logs a completed API call. The response payload is only emitted at debug level.
+func logAPIResponse(l logger.Logger, api, sessionID, requestID string, start time.Time, body any) {
+	fields := append([]any{logger.KeyAPI, api}, sessionField(sessionID)...)
+	fields = append(fields, logger.KeyRequestID, requestID, logger.KeyDuration, time.Since(start))
+	if body != nil {
+		fields = append(fields, "response", formatPayload(body))
+	}
+	l.Debug("API response", fields...)
+}
+
+// logAPIError logs a failed API call
+func logAPIError(l logger.Logger, api, sessionID string, start time.Time, err error) {
+	fields := append([]any{logger.KeyAPI, api}, sessionField(sessionID)...)
+	l.Error("API call failed", append(fields, logger.KeyDuration, time.Since(start), logger.KeyError, err)...)
+}
+
+func sessionField(sessionID string) []any {
+	if sessionID == "" {
+		return nil
+	}
+	return []any{logger.KeySessionID, sessionID}
+}
+
+// formatPayload renders an API payload as compact JSON for debug logging
+func formatPayload(body any) string {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return ""
+	}
+	// Replace \u0026 with & for better readability
+	return strings.ReplaceAll(string(data), "\\u0026", "&")
+}
@@ -7,6 +7,7 @@ import (
 
 	mcp "github.com/aliyun/wuying-agentbay-sdk/golang/api/client"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/command"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/logger"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
 )
 
@@ -565,7 +566,8 @@ func (m *Mobile) executeTemplateCommand(commandTemplate, description string) err
 		return fmt.Errorf("command service not available")
 	}
 
-	fmt.Printf("Executing %s\n", description)
+	log := logger.From(m.Session)
+	log.Debug("Executing mobile command", "description", description)
 
 	result, err := m.command.ExecuteCommand(commandTemplate)
 	if err != nil {
@@ -573,7 +575,7 @@ func (m *Mobile) executeTemplateCommand(commandTemplate, description string) err
 	}
 
 	if result != nil && result.Output != "" {
-		fmt.Printf("✅ %s completed successfully\n", description)
+		log.Info("Mobile command completed successfully", "description", description)
 	}
 
 	return nil
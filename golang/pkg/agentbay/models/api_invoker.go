@@ -0,0 +1,32 @@
+package models
+
+import (
+	"context"
+
+	"github.com/alibabacloud-go/tea/dara"
+)
+
+// APICall performs a single OpenAPI request with the given runtime options
+type APICall func(runtime *dara.RuntimeOptions) (interface{}, error)
+
+// APIInvoker is implemented by sessions that run OpenAPI calls on behalf of service packages,
+// applying cancellation, the client's retry policy and typed error conversion.
+type APIInvoker interface {
+	InvokeAPI(ctx context.Context, api string, call APICall) (interface{}, int, error)
+}
+
+// InvokeAPI runs call through invoker when it implements APIInvoker, and directly otherwise.
+// It returns the response, the number of retries performed and any error.
+func InvokeAPI(ctx context.Context, invoker interface{}, api string, call APICall) (interface{}, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	if apiInvoker, ok := invoker.(APIInvoker); ok {
+		return apiInvoker.InvokeAPI(ctx, api, call)
+	}
+	response, err := call(&dara.RuntimeOptions{})
+	if err != nil {
+		return response, 0, &APIError{API: api, Message: err.Error(), Err: err}
+	}
+	return response, 0, nil
+}
@@ -0,0 +1,241 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Sentinel errors that typed AgentBay errors match through errors.Is
+var (
+	// ErrAuthentication reports that the API key was rejected
+	ErrAuthentication = errors.New("authentication failed")
+	// ErrSessionNotFound reports that a session does not exist or has already been released
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+// authenticationCodes are API error codes that indicate a rejected API key
+var authenticationCodes = []string{
+	"InvalidApiKey",
+	"InvalidApiKey.NotFound",
+	"InvalidAccessKeyId",
+	"Unauthorized",
+	"Forbidden",
+	"AuthFailed",
+}
+
+// APIError is returned when an AgentBay API call fails, either at the transport level or
+// because the API responded with an error code.
+type APIError struct {
+	API        string // Name of the API that failed, e.g. "CreateMcpSession"
+	Code       string // Error code returned by the API, if any
+	Message    string // Error message returned by the API or the transport error text
+	RequestID  string // Request ID returned by the API, if any
+	HTTPStatus int    // HTTP status code of the response, or 0 if none was received
+	Err        error  // Underlying error, if any
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	var b strings.Builder
+	if e.API != "" {
+		b.WriteString(e.API)
+		b.WriteString(" failed: ")
+	}
+	if e.Code != "" {
+		fmt.Fprintf(&b, "[%s] ", e.Code)
+	}
+	switch {
+	case e.Message != "":
+		b.WriteString(e.Message)
+	case e.Err != nil:
+		b.WriteString(e.Err.Error())
+	default:
+		b.WriteString("API error")
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (RequestID: %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// Unwrap returns the underlying error
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the API error matches target.
+// An APIError matches ErrAuthentication when the API rejected the credentials.
+func (e *APIError) Is(target error) bool {
+	if target == ErrAuthentication {
+		return e.IsAuthentication()
+	}
+	return false
+}
+
+// IsAuthentication reports whether the API rejected the credentials
+func (e *APIError) IsAuthentication() bool {
+	if e.HTTPStatus == http.StatusUnauthorized || e.HTTPStatus == http.StatusForbidden {
+		return true
+	}
+	for _, code := range authenticationCodes {
+		if strings.EqualFold(e.Code, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// SessionNotFoundError is returned when a session does not exist or has already been released.
+// It matches ErrSessionNotFound through errors.Is.
+type SessionNotFoundError struct {
+	SessionID string
+	RequestID string
+	Err       error // Underlying API error, if any
+}
+
+// Error implements the error interface
+func (e *SessionNotFoundError) Error() string {
+	msg := fmt.Sprintf("session %s not found", e.SessionID)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error
+func (e *SessionNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrSessionNotFound
+func (e *SessionNotFoundError) Is(target error) bool {
+	return target == ErrSessionNotFound
+}
+
+// ToolError is returned when an MCP tool call completed but the tool reported a failure
+type ToolError struct {
+	Tool      string // Name of the MCP tool
+	Message   string // Error message reported by the tool
+	RequestID string
+}
+
+// NewToolError creates a ToolError from an unsuccessful tool result
+func NewToolError(tool string, result *McpToolResult) *ToolError {
+	if result == nil {
+		return &ToolError{Tool: tool}
+	}
+	return &ToolError{
+		Tool:      tool,
+		Message:   result.ErrorMessage,
+		RequestID: result.RequestID,
+	}
+}
+
+// Error implements the error interface
+func (e *ToolError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("tool %s failed", e.Tool)
+	}
+	return e.Message
+}
+
+// FileError is returned by file system operations. It matches fs.ErrNotExist,
+// fs.ErrPermission and fs.ErrExist through errors.Is when the tool reported a missing
+// file, a permission problem or an existing file.
+type FileError struct {
+	Op   string // Operation that failed, e.g. "read file"
+	Path string // Path the operation was applied to
+	Err  error  // Underlying error
+}
+
+// Error implements the error interface
+func (e *FileError) Error() string {
+	if e.Path == "" {
+		return e.Op + ": " + e.Err.Error()
+	}
+	return e.Op + " " + e.Path + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the file error matches target.
+func (e *FileError) Is(target error) bool {
+	var toolErr *ToolError
+	if !errors.As(e.Err, &toolErr) {
+		return false
+	}
+	msg := strings.ToLower(toolErr.Message)
+	switch target {
+	case fs.ErrNotExist:
+		return strings.Contains(msg, "no such file") || strings.Contains(msg, "not found") ||
+			strings.Contains(msg, "does not exist")
+	case fs.ErrPermission:
+		return strings.Contains(msg, "permission denied") || strings.Contains(msg, "access denied")
+	case fs.ErrExist:
+		return strings.Contains(msg, "file exists") || strings.Contains(msg, "already exists")
+	}
+	return false
+}
+
+// CommandError is returned when a command could not be executed in the session
+type CommandError struct {
+	Command  string // Command line that was executed
+	ExitCode int    // Exit code of the command, or 0 if unknown
+	Err      error  // Underlying error
+}
+
+// Error implements the error interface
+func (e *CommandError) Error() string {
+	if e.ExitCode > 0 {
+		return fmt.Sprintf("command execution failed with exit code %d: %v", e.ExitCode, e.Err)
+	}
+	return fmt.Sprintf("command execution failed: %v", e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// TimeoutError is returned when an operation did not complete within its time budget.
+// It matches context.DeadlineExceeded through errors.Is.
+type TimeoutError struct {
+	Op       string        // Operation that timed out
+	Duration time.Duration // Time budget of the operation, or 0 if unknown
+	Err      error         // Underlying error, if any
+}
+
+// Error implements the error interface
+func (e *TimeoutError) Error() string {
+	msg := e.Op + " timed out"
+	if e.Duration > 0 {
+		msg += fmt.Sprintf(" after %v", e.Duration)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is context.DeadlineExceeded
+func (e *TimeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// Timeout reports that the error is a timeout, for callers checking net.Error-style errors
+func (e *TimeoutError) Timeout() bool {
+	return true
+}
@@ -6,7 +6,8 @@ import (
 
 // ApiResponse is the base class for all API responses, containing RequestID
 type ApiResponse struct {
-	RequestID string // Unique identifier for the API request
+	RequestID  string // Unique identifier for the API request
+	RetryCount int    // Number of times the API call was retried after a transient failure
 }
 
 // GetRequestID returns the unique identifier for the API request
@@ -58,4 +59,5 @@ type McpToolResult struct {
 	Data         string `json:"data"`
 	ErrorMessage string `json:"error_message"`
 	RequestID    string `json:"request_id"`
+	RetryCount   int    `json:"retry_count,omitempty"`
 }
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"context"
+)
+
+// McpToolCaller is implemented by sessions that can invoke MCP tools
+type McpToolCaller interface {
+	CallMcpTool(toolName string, args interface{}) (*McpToolResult, error)
+}
+
+// ContextMcpToolCaller is implemented by sessions whose MCP tool calls can be cancelled through a context
+type ContextMcpToolCaller interface {
+	CallMcpToolWithContext(ctx context.Context, toolName string, args interface{}) (*McpToolResult, error)
+}
+
+// CallMcpToolWithContext invokes an MCP tool on caller, honouring ctx.
+// Callers that implement ContextMcpToolCaller have the context threaded through the request;
+// for others the context is checked before the call is made.
+func CallMcpToolWithContext(ctx context.Context, caller McpToolCaller, toolName string, args interface{}) (*McpToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if contextCaller, ok := caller.(ContextMcpToolCaller); ok {
+		return contextCaller.CallMcpToolWithContext(ctx, toolName, args)
+	}
+	return caller.CallMcpTool(toolName, args)
+}
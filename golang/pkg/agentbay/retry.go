@@ -0,0 +1,188 @@
+package agentbay
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/logger"
+)
+
+// RetryPolicy controls how API calls that fail with a transient error are retried.
+// Calls that are not idempotent, such as CreateMcpSession and CallMcpTool, are only retried
+// when the request was certainly not processed (throttling or a refused connection), unless
+// RetryNonIdempotent is set.
+type RetryPolicy struct {
+	MaxAttempts        int                  `json:"max_attempts"`         // Total number of attempts including the first one; 1 disables retries
+	InitialBackoff     time.Duration        `json:"initial_backoff"`      // Delay before the first retry
+	MaxBackoff         time.Duration        `json:"max_backoff"`          // Upper bound for the delay between attempts
+	Multiplier         float64              `json:"multiplier"`           // Factor applied to the delay after each retry
+	Jitter             float64              `json:"jitter"`               // Fraction of the delay (0-1) that is randomized
+	Retryable          func(err error) bool `json:"-"`                    // Classifies retryable errors, given as *APIError or *TimeoutError; nil means IsRetryableError
+	RetryNonIdempotent bool                 `json:"retry_non_idempotent"` // Also retry non-idempotent calls on any retryable error
+}
+
+// DefaultRetryPolicy returns the retry policy used when Config.Retry is not set
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// NoRetry returns a retry policy that performs every API call exactly once
+func NoRetry() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 1}
+}
+
+// nonIdempotentAPIs lists the API actions that must not be repeated once the server may have
+// processed them, since a retry could create a duplicate session or run a tool twice.
+var nonIdempotentAPIs = map[string]bool{
+	"CreateMcpSession": true,
+	"CallMcpTool":      true,
+}
+
+// Backoff returns the delay before retry number retry (starting at 1), including jitter
+func (p *RetryPolicy) Backoff(retry int) time.Duration {
+	if retry < 1 || p.InitialBackoff <= 0 {
+		return 0
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if jitter := math.Min(p.Jitter, 1); jitter > 0 {
+		delay += delay * jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay)
+}
+
+// shouldRetry reports whether a call to api that failed with err may be attempted again. Calls
+// are not retried once ctx is done; a timeout of the transport itself is retryable.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, api string, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = IsRetryableError
+	}
+	if !retryable(err) {
+		return false
+	}
+	if nonIdempotentAPIs[api] && !p.RetryNonIdempotent {
+		return isThrottlingError(err) || isConnectionRefused(err)
+	}
+	return true
+}
+
+// IsRetryableError reports whether err is a transient failure worth retrying: throttling,
+// a 5xx response, a network timeout or a dropped connection. It is the default classifier
+// of RetryPolicy.
+func IsRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	// A *TimeoutError matches context.DeadlineExceeded, so timeouts are checked before the
+	// context error, which is only final when it is not a transport timeout
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() && netErr != context.DeadlineExceeded {
+		return true
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if isThrottlingError(err) || isConnectionRefused(err) {
+		return true
+	}
+	if apiErr := asAPIError(err); apiErr != nil && apiErr.HTTPStatus != 0 {
+		return apiErr.HTTPStatus >= http.StatusInternalServerError && apiErr.HTTPStatus != http.StatusNotImplemented
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// isThrottlingError reports whether the API rejected the request because of rate limiting
+func isThrottlingError(err error) bool {
+	apiErr := asAPIError(err)
+	if apiErr == nil {
+		return false
+	}
+	return apiErr.HTTPStatus == http.StatusTooManyRequests || strings.Contains(strings.ToLower(apiErr.Code), "throttling")
+}
+
+// isConnectionRefused reports whether the request never reached the server
+func isConnectionRefused(err error) bool {
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// asAPIError returns the *APIError describing err, converting raw OpenAPI client errors
+func asAPIError(err error) *APIError {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && (apiErr.Code != "" || apiErr.HTTPStatus != 0) {
+		return apiErr
+	}
+	if errors.As(newAPIError("", err), &apiErr) {
+		return apiErr
+	}
+	return nil
+}
+
+// retryPolicyProvider is implemented by types that carry a retry policy, such as Session
+type retryPolicyProvider interface {
+	GetRetryPolicy() *RetryPolicy
+}
+
+// retryPolicyFrom returns the retry policy of v if it provides one, and DefaultRetryPolicy otherwise
+func retryPolicyFrom(v any) *RetryPolicy {
+	if p, ok := v.(retryPolicyProvider); ok {
+		if policy := p.GetRetryPolicy(); policy != nil {
+			return policy
+		}
+	}
+	return DefaultRetryPolicy()
+}
+
+// invokeWithRetry runs an OpenAPI call through invokeWithContext, retrying transient failures
+// according to policy. It returns the number of retries that were performed, which callers
+// report through ApiResponse.RetryCount.
+func invokeWithRetry[T any](ctx context.Context, policy *RetryPolicy, log logger.Logger, api string, call func(runtime *dara.RuntimeOptions) (T, error)) (T, int, error) {
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
+
+	retries := 0
+	for {
+		value, err := invokeWithContext(ctx, call)
+		if err == nil || retries+1 >= policy.MaxAttempts || !policy.shouldRetry(ctx, api, newAPIError(api, err)) {
+			return value, retries, err
+		}
+
+		retries++
+		delay := policy.Backoff(retries)
+		log.Warn("Retrying API call after transient failure",
+			logger.KeyAPI, api, "attempt", retries+1, "backoff", delay, logger.KeyError, err)
+		if sleepErr := sleepWithContext(ctx, delay); sleepErr != nil {
+			var zero T
+			return zero, retries, sleepErr
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package agentbay
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -10,19 +12,21 @@ import (
 
 	"math/rand"
 
+	"github.com/alibabacloud-go/tea/dara"
 	"github.com/alibabacloud-go/tea/tea"
 	mcp "github.com/aliyun/wuying-agentbay-sdk/golang/api/client"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/agent"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/application"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/browser"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/code"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/command"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/computer"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/filesystem"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/logger"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/mobile"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/oss"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/ui"
-	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/utils"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/window"
 )
 
@@ -131,6 +135,9 @@ type Session struct {
 	Application *application.ApplicationManager
 	Window      *window.WindowManager
 
+	// Cloud browser, driven over CDP after initialization
+	Browser *browser.Browser
+
 	// Platform-specific automation modules
 	Computer *computer.Computer
 	Mobile   *mobile.Mobile
@@ -165,6 +172,9 @@ func NewSession(agentBay *AgentBay, sessionID string) *Session {
 	session.Application = application.NewApplicationManager(session)
 	session.Window = window.NewWindowManager(session)
 
+	// Initialize browser
+	session.Browser = browser.NewBrowser(session)
+
 	// Initialize platform-specific automation modules
 	session.Computer = computer.NewComputer(session)
 	session.Mobile = mobile.NewMobile(session)
@@ -182,9 +192,10 @@ func (s *Session) GetAPIKey() string {
 	return s.AgentBay.APIKey
 }
 
-// GetClient returns the HTTP client for this session.
+// GetClient returns the API client for this session. It is a copy of AgentBay.Client, so that
+// calls made concurrently through different copies do not race.
 func (s *Session) GetClient() *mcp.Client {
-	return s.AgentBay.Client
+	return s.AgentBay.apiClient()
 }
 
 // GetSessionId returns the session ID for this session.
@@ -192,6 +203,29 @@ func (s *Session) GetSessionId() string {
 	return s.SessionID
 }
 
+// GetLogger returns the logger used by this session, which is inherited from its AgentBay client.
+func (s *Session) GetLogger() logger.Logger {
+	if s.AgentBay == nil {
+		return logger.Default()
+	}
+	return s.AgentBay.GetLogger()
+}
+
+// GetRetryPolicy returns the retry policy applied to API calls made by this session
+func (s *Session) GetRetryPolicy() *RetryPolicy {
+	return s.AgentBay.GetRetryPolicy()
+}
+
+// InvokeAPI runs an OpenAPI call on behalf of a service package, honouring ctx and applying the
+// client's retry policy. Errors are converted to the SDK's typed errors.
+func (s *Session) InvokeAPI(ctx context.Context, api string, call models.APICall) (interface{}, int, error) {
+	response, retries, err := invokeWithRetry(ctx, s.GetRetryPolicy(), s.GetLogger(), api, call)
+	if err != nil {
+		return response, retries, sessionError(s.SessionID, newAPIError(api, err))
+	}
+	return response, retries, nil
+}
+
 // GetCommand returns the command handler for this session.
 func (s *Session) GetCommand() *command.Command {
 	return s.Command
@@ -199,25 +233,32 @@ func (s *Session) GetCommand() *command.Command {
 
 // Delete deletes this session.
 func (s *Session) Delete(syncContext ...bool) (*DeleteResult, error) {
+	return s.DeleteWithContext(context.Background(), syncContext...)
+}
+
+// DeleteWithContext deletes this session, honouring the cancellation and deadline of ctx for
+// the optional context synchronization and the release call.
+func (s *Session) DeleteWithContext(ctx context.Context, syncContext ...bool) (*DeleteResult, error) {
 	shouldSync := len(syncContext) > 0 && syncContext[0]
+	log := s.GetLogger()
 
 	// If syncContext is true, trigger file uploads first
 	if shouldSync {
-		fmt.Println("Triggering context synchronization before session deletion...")
+		log.Info("Triggering context synchronization before session deletion", logger.KeySessionID, s.SessionID)
 		syncStartTime := time.Now()
 
 		// Use the new sync method without callback (sync mode)
-		syncResult, err := s.Context.SyncWithCallback("", "", "", nil, 150, 1500)
+		syncResult, err := s.Context.SyncWithCallbackContext(ctx, "", "", "", nil, 150, 1500)
 		if err != nil {
 			syncDuration := time.Since(syncStartTime)
-			fmt.Printf("Warning: Failed to trigger context sync after %v: %v\n", syncDuration, err)
+			log.Warn("Failed to trigger context sync", logger.KeySessionID, s.SessionID, logger.KeyDuration, syncDuration, logger.KeyError, err)
 			// Continue with deletion even if sync fails
 		} else {
 			syncDuration := time.Since(syncStartTime)
 			if syncResult.Success {
-				fmt.Printf("Context sync completed successfully in %v\n", syncDuration)
+				log.Info("Context sync completed successfully", logger.KeySessionID, s.SessionID, logger.KeyDuration, syncDuration)
 			} else {
-				fmt.Printf("Context sync completed with failures after %v\n", syncDuration)
+				log.Warn("Context sync completed with failures", logger.KeySessionID, s.SessionID, logger.KeyDuration, syncDuration)
 			}
 		}
 	}
@@ -228,22 +269,25 @@ func (s *Session) Delete(syncContext ...bool) (*DeleteResult, error) {
 	}
 
 	// Log API request
-	fmt.Println("API Call: ReleaseMcpSession")
-	fmt.Printf("Request: SessionId=%s\n", *releaseSessionRequest.SessionId)
+	logAPIRequest(log, "ReleaseMcpSession", s.SessionID)
+
+	start := time.Now()
 
-	response, err := s.GetClient().ReleaseMcpSession(releaseSessionRequest)
+	response, retries, err := invokeWithRetry(ctx, s.GetRetryPolicy(), log, "ReleaseMcpSession", func(runtime *dara.RuntimeOptions) (*mcp.ReleaseMcpSessionResponse, error) {
+		return s.GetClient().ReleaseMcpSessionWithOptions(releaseSessionRequest, runtime)
+	})
 
 	// Log API response
 	if err != nil {
-		fmt.Println("Error calling ReleaseMcpSession:", err)
-		return nil, err
+		logAPIError(log, "ReleaseMcpSession", s.SessionID, start, err)
+		return nil, sessionError(s.SessionID, newAPIError("ReleaseMcpSession", err))
 	}
 
 	// Extract RequestID
 	requestID := models.ExtractRequestID(response)
 
 	if response != nil && response.Body != nil {
-		fmt.Println("Response from ReleaseMcpSession:", response.Body)
+		logAPIResponse(log, "ReleaseMcpSession", s.SessionID, requestID, start, response.Body)
 	}
 
 	// Check for API-level errors
@@ -255,13 +299,16 @@ func (s *Session) Delete(syncContext ...bool) (*DeleteResult, error) {
 			} else if response.Body.Code != nil {
 				errorMsg = fmt.Sprintf("[%s] Failed to delete session", *response.Body.Code)
 			}
+			apiErr := newAPIResponseError("ReleaseMcpSession", tea.StringValue(response.Body.Code),
+				tea.StringValue(response.Body.Message), requestID)
 			return &DeleteResult{
 				ApiResponse: models.ApiResponse{
-					RequestID: requestID,
+					RequestID:  requestID,
+					RetryCount: retries,
 				},
 				Success:      false,
 				ErrorMessage: errorMsg,
-			}, nil
+			}, sessionError(s.SessionID, apiErr)
 		}
 	}
 
@@ -269,7 +316,8 @@ func (s *Session) Delete(syncContext ...bool) (*DeleteResult, error) {
 
 	return &DeleteResult{
 		ApiResponse: models.ApiResponse{
-			RequestID: requestID,
+			RequestID:  requestID,
+			RetryCount: retries,
 		},
 		Success: true,
 	}, nil
@@ -306,6 +354,11 @@ func (s *Session) ValidateLabels(labels map[string]string) string {
 
 // SetLabels sets the labels for this session.
 func (s *Session) SetLabels(labels map[string]string) (*LabelResult, error) {
+	return s.SetLabelsWithContext(context.Background(), labels)
+}
+
+// SetLabelsWithContext sets the labels for this session, honouring the cancellation and deadline of ctx.
+func (s *Session) SetLabelsWithContext(ctx context.Context, labels map[string]string) (*LabelResult, error) {
 	// Validate labels using the validation function
 	if validationError := s.ValidateLabels(labels); validationError != "" {
 		return &LabelResult{
@@ -334,27 +387,32 @@ func (s *Session) SetLabels(labels map[string]string) (*LabelResult, error) {
 	}
 
 	// Log API request
-	fmt.Println("API Call: SetLabel")
-	fmt.Printf("Request: SessionId=%s, Labels=%s\n", *setLabelRequest.SessionId, *setLabelRequest.Labels)
+	log := s.GetLogger()
+	logAPIRequest(log, "SetLabel", s.SessionID, "labels", *setLabelRequest.Labels)
+
+	start := time.Now()
 
-	response, err := s.GetClient().SetLabel(setLabelRequest)
+	response, retries, err := invokeWithRetry(ctx, s.GetRetryPolicy(), log, "SetLabel", func(runtime *dara.RuntimeOptions) (*mcp.SetLabelResponse, error) {
+		return s.GetClient().SetLabelWithOptions(setLabelRequest, runtime)
+	})
 
 	// Log API response
 	if err != nil {
-		fmt.Println("Error calling SetLabel:", err)
-		return nil, err
+		logAPIError(log, "SetLabel", s.SessionID, start, err)
+		return nil, sessionError(s.SessionID, newAPIError("SetLabel", err))
 	}
 
 	// Extract RequestID
 	requestID := models.ExtractRequestID(response)
 
 	if response != nil && response.Body != nil {
-		fmt.Println("Response from SetLabel:", response.Body)
+		logAPIResponse(log, "SetLabel", s.SessionID, requestID, start, response.Body)
 	}
 
 	return &LabelResult{
 		ApiResponse: models.ApiResponse{
-			RequestID: requestID,
+			RequestID:  requestID,
+			RetryCount: retries,
 		},
 		Labels: string(labelsJSON),
 	}, nil
@@ -362,28 +420,37 @@ func (s *Session) SetLabels(labels map[string]string) (*LabelResult, error) {
 
 // GetLabels gets the labels for this session.
 func (s *Session) GetLabels() (*LabelResult, error) {
+	return s.GetLabelsWithContext(context.Background())
+}
+
+// GetLabelsWithContext gets the labels for this session, honouring the cancellation and deadline of ctx.
+func (s *Session) GetLabelsWithContext(ctx context.Context) (*LabelResult, error) {
 	getLabelRequest := &mcp.GetLabelRequest{
 		Authorization: tea.String("Bearer " + s.GetAPIKey()),
 		SessionId:     tea.String(s.SessionID),
 	}
 
 	// Log API request
-	fmt.Println("API Call: GetLabel")
-	fmt.Printf("Request: SessionId=%s\n", *getLabelRequest.SessionId)
+	log := s.GetLogger()
+	logAPIRequest(log, "GetLabel", s.SessionID)
 
-	response, err := s.GetClient().GetLabel(getLabelRequest)
+	start := time.Now()
+
+	response, retries, err := invokeWithRetry(ctx, s.GetRetryPolicy(), log, "GetLabel", func(runtime *dara.RuntimeOptions) (*mcp.GetLabelResponse, error) {
+		return s.GetClient().GetLabelWithOptions(getLabelRequest, runtime)
+	})
 
 	// Log API response
 	if err != nil {
-		fmt.Println("Error calling GetLabel:", err)
-		return nil, err
+		logAPIError(log, "GetLabel", s.SessionID, start, err)
+		return nil, sessionError(s.SessionID, newAPIError("GetLabel", err))
 	}
 
 	// Extract RequestID
 	requestID := models.ExtractRequestID(response)
 
 	if response != nil && response.Body != nil {
-		fmt.Println("Response from GetLabel:", response.Body)
+		logAPIResponse(log, "GetLabel", s.SessionID, requestID, start, response.Body)
 	}
 
 	var labels string
@@ -393,7 +460,8 @@ func (s *Session) GetLabels() (*LabelResult, error) {
 
 	return &LabelResult{
 		ApiResponse: models.ApiResponse{
-			RequestID: requestID,
+			RequestID:  requestID,
+			RetryCount: retries,
 		},
 		Labels: labels,
 	}, nil
@@ -401,6 +469,11 @@ func (s *Session) GetLabels() (*LabelResult, error) {
 
 // GetLink gets the link for this session.
 func (s *Session) GetLink(protocolType *string, port *int32) (*LinkResult, error) {
+	return s.GetLinkWithContext(context.Background(), protocolType, port)
+}
+
+// GetLinkWithContext gets the link for this session, honouring the cancellation and deadline of ctx.
+func (s *Session) GetLinkWithContext(ctx context.Context, protocolType *string, port *int32) (*LinkResult, error) {
 	// Validate port range if port is provided
 	if port != nil {
 		if *port < 30100 || *port > 30199 {
@@ -416,35 +489,32 @@ func (s *Session) GetLink(protocolType *string, port *int32) (*LinkResult, error
 	}
 
 	// Log API request
-	fmt.Println("API Call: GetLink")
-	fmt.Printf("Request: SessionId=%s", *getLinkRequest.SessionId)
-	if getLinkRequest.ProtocolType != nil {
-		fmt.Printf(", ProtocolType=%s", *getLinkRequest.ProtocolType)
-	}
-	if getLinkRequest.Port != nil {
-		fmt.Printf(", Port=%d", *getLinkRequest.Port)
-	}
-	fmt.Println()
+	log := s.GetLogger()
+	logAPIRequest(log, "GetLink", s.SessionID,
+		"protocol_type", tea.StringValue(protocolType), "port", tea.Int32Value(port))
+
+	start := time.Now()
 
-	response, err := s.GetClient().GetLink(getLinkRequest)
+	response, retries, err := invokeWithRetry(ctx, s.GetRetryPolicy(), log, "GetLink", func(runtime *dara.RuntimeOptions) (*mcp.GetLinkResponse, error) {
+		return s.GetClient().GetLinkWithOptions(getLinkRequest, runtime)
+	})
 
 	// Log API response
 	if err != nil {
-		fmt.Println("Error calling GetLink:", err)
-		return nil, err
+		logAPIError(log, "GetLink", s.SessionID, start, err)
+		return nil, sessionError(s.SessionID, newAPIError("GetLink", err))
 	}
 
 	// Extract RequestID
 	requestID := models.ExtractRequestID(response)
 
 	if response != nil && response.Body != nil {
-		fmt.Println("Response from GetLink:", response.Body)
+		logAPIResponse(log, "GetLink", s.SessionID, requestID, start, response.Body)
 	}
 
 	var link string
 	if response != nil && response.Body != nil && response.Body.Data != nil {
 		data := response.Body.Data
-		fmt.Printf("Data: %v\n", data)
 		if data.Url != nil {
 			link = *data.Url
 		}
@@ -452,7 +522,8 @@ func (s *Session) GetLink(protocolType *string, port *int32) (*LinkResult, error
 
 	return &LinkResult{
 		ApiResponse: models.ApiResponse{
-			RequestID: requestID,
+			RequestID:  requestID,
+			RetryCount: retries,
 		},
 		Link: link,
 	}, nil
@@ -460,28 +531,43 @@ func (s *Session) GetLink(protocolType *string, port *int32) (*LinkResult, error
 
 // Info gets information about this session.
 func (s *Session) Info() (*InfoResult, error) {
+	return s.InfoWithContext(context.Background())
+}
+
+// InfoWithContext gets information about this session, honouring the cancellation and deadline of ctx.
+func (s *Session) InfoWithContext(ctx context.Context) (*InfoResult, error) {
 	getMcpResourceRequest := &mcp.GetMcpResourceRequest{
 		Authorization: tea.String("Bearer " + s.GetAPIKey()),
 		SessionId:     tea.String(s.SessionID),
 	}
 
 	// Log API request
-	fmt.Println("API Call: GetMcpResource")
-	fmt.Printf("Request: SessionId=%s\n", *getMcpResourceRequest.SessionId)
+	log := s.GetLogger()
+	logAPIRequest(log, "GetMcpResource", s.SessionID)
 
-	response, err := s.GetClient().GetMcpResource(getMcpResourceRequest)
+	start := time.Now()
+
+	response, retries, err := invokeWithRetry(ctx, s.GetRetryPolicy(), log, "GetMcpResource", func(runtime *dara.RuntimeOptions) (*mcp.GetMcpResourceResponse, error) {
+		return s.GetClient().GetMcpResourceWithOptions(getMcpResourceRequest, runtime)
+	})
 
 	// Log API response
 	if err != nil {
-		fmt.Println("Error calling GetMcpResource:", err)
-		return nil, err
+		logAPIError(log, "GetMcpResource", s.SessionID, start, err)
+		return nil, sessionError(s.SessionID, newAPIError("GetMcpResource", err))
 	}
 
 	// Extract RequestID
 	requestID := models.ExtractRequestID(response)
 
 	if response != nil && response.Body != nil {
-		fmt.Println("Response from GetMcpResource:", response.Body)
+		logAPIResponse(log, "GetMcpResource", s.SessionID, requestID, start, response.Body)
+	}
+
+	// Check for API-level errors
+	if response != nil && response.Body != nil && response.Body.Success != nil && !*response.Body.Success {
+		return nil, sessionError(s.SessionID, newAPIResponseError("GetMcpResource",
+			tea.StringValue(response.Body.Code), tea.StringValue(response.Body.Message), requestID))
 	}
 
 	if response != nil && response.Body != nil && response.Body.Data != nil {
@@ -528,18 +614,25 @@ func (s *Session) Info() (*InfoResult, error) {
 
 		return &InfoResult{
 			ApiResponse: models.ApiResponse{
-				RequestID: requestID,
+				RequestID:  requestID,
+				RetryCount: retries,
 			},
 			Info: sessionInfo,
 		}, nil
 	}
 
-	return nil, fmt.Errorf("failed to get session info: empty response data")
+	return nil, &APIError{API: "GetMcpResource", Message: "empty response data", RequestID: requestID}
 }
 
 // ListMcpTools lists MCP tools available for this session.
 // It uses the ImageId from the session creation, or "linux_latest" as default.
 func (s *Session) ListMcpTools() (*McpToolsResult, error) {
+	return s.ListMcpToolsWithContext(context.Background())
+}
+
+// ListMcpToolsWithContext lists MCP tools available for this session, honouring the
+// cancellation and deadline of ctx.
+func (s *Session) ListMcpToolsWithContext(ctx context.Context) (*McpToolsResult, error) {
 	// Use session's ImageId, or default to "linux_latest" if empty
 	imageId := s.ImageId
 	if imageId == "" {
@@ -552,22 +645,26 @@ func (s *Session) ListMcpTools() (*McpToolsResult, error) {
 	}
 
 	// Log API request
-	fmt.Println("API Call: ListMcpTools")
-	fmt.Printf("Request: ImageId=%s\n", *listMcpToolsRequest.ImageId)
+	log := s.GetLogger()
+	logAPIRequest(log, "ListMcpTools", s.SessionID, "image_id", imageId)
+
+	start := time.Now()
 
-	response, err := s.GetClient().ListMcpTools(listMcpToolsRequest)
+	response, retries, err := invokeWithRetry(ctx, s.GetRetryPolicy(), log, "ListMcpTools", func(runtime *dara.RuntimeOptions) (*mcp.ListMcpToolsResponse, error) {
+		return s.GetClient().ListMcpToolsWithOptions(listMcpToolsRequest, runtime)
+	})
 
 	// Log API response
 	if err != nil {
-		fmt.Println("Error calling ListMcpTools:", err)
-		return nil, err
+		logAPIError(log, "ListMcpTools", s.SessionID, start, err)
+		return nil, sessionError(s.SessionID, newAPIError("ListMcpTools", err))
 	}
 
 	// Extract RequestID
 	requestID := models.ExtractRequestID(response)
 
 	if response != nil && response.Body != nil {
-		fmt.Println("Response from ListMcpTools:", response.Body)
+		logAPIResponse(log, "ListMcpTools", s.SessionID, requestID, start, response.Body)
 	}
 
 	// Parse the response data
@@ -576,10 +673,11 @@ func (s *Session) ListMcpTools() (*McpToolsResult, error) {
 		// The Data field is a JSON string, so we need to unmarshal it
 		var toolsData []map[string]interface{}
 		if err := json.Unmarshal([]byte(*response.Body.Data), &toolsData); err != nil {
-			fmt.Printf("Error unmarshaling tools data: %v\n", err)
+			log.Error("Failed to unmarshal tools data", logger.KeySessionID, s.SessionID, logger.KeyError, err)
 			return &McpToolsResult{
 				ApiResponse: models.ApiResponse{
-					RequestID: requestID,
+					RequestID:  requestID,
+					RetryCount: retries,
 				},
 				Tools: []McpTool{},
 			}, nil
@@ -611,7 +709,8 @@ func (s *Session) ListMcpTools() (*McpToolsResult, error) {
 
 	return &McpToolsResult{
 		ApiResponse: models.ApiResponse{
-			RequestID: requestID,
+			RequestID:  requestID,
+			RetryCount: retries,
 		},
 		Tools: tools,
 	}, nil
@@ -656,8 +755,17 @@ func (s *Session) FindServerForTool(toolName string) string {
 	return ""
 }
 
-// CallMcpTool calls the MCP tool and handles both VPC and non-VPC scenarios
+// CallMcpTool calls the MCP tool and handles both VPC and non-VPC scenarios.
+// If the request cannot be completed, the failed result is returned together with an
+// *APIError (or *SessionNotFoundError); a failure reported by the tool itself is returned
+// as a result with Success set to false and a nil error.
 func (s *Session) CallMcpTool(toolName string, args interface{}) (*models.McpToolResult, error) {
+	return s.CallMcpToolWithContext(context.Background(), toolName, args)
+}
+
+// CallMcpToolWithContext calls the MCP tool like CallMcpTool, but aborts the request and
+// returns ctx.Err() when ctx is cancelled or its deadline expires.
+func (s *Session) CallMcpToolWithContext(ctx context.Context, toolName string, args interface{}) (*models.McpToolResult, error) {
 	// Marshal arguments to JSON
 	argsJSON, err := json.Marshal(args)
 	if err != nil {
@@ -671,42 +779,43 @@ func (s *Session) CallMcpTool(toolName string, args interface{}) (*models.McpToo
 
 	// Check if this is a VPC session
 	if s.IsVpc() {
-		return s.callMcpToolVPC(toolName, string(argsJSON))
+		return s.callMcpToolVPC(ctx, toolName, string(argsJSON))
 	}
 
 	// Non-VPC mode: use traditional API call
-	return s.callMcpToolAPI(toolName, string(argsJSON))
+	return s.callMcpToolAPI(ctx, toolName, string(argsJSON))
 }
 
 // callMcpToolVPC handles VPC-based MCP tool calls
-func (s *Session) callMcpToolVPC(toolName, argsJSON string) (*models.McpToolResult, error) {
+func (s *Session) callMcpToolVPC(ctx context.Context, toolName, argsJSON string) (*models.McpToolResult, error) {
 	// VPC mode: Use HTTP request to the VPC endpoint
-	fmt.Println("API Call: CallMcpTool (VPC) -", toolName)
-	fmt.Printf("Request: Args=%s\n", argsJSON)
+	log := s.GetLogger()
+	logAPIRequest(log, "CallMcpTool", s.SessionID, "tool", toolName, "vpc", true, "args", argsJSON)
+	start := time.Now()
 
 	// Find server for this tool
 	server := s.FindServerForTool(toolName)
 	if server == "" {
 		sanitizedErr := fmt.Sprintf("server not found for tool: %s", toolName)
-		fmt.Println("Error calling VPC CallMcpTool -", toolName, ":", sanitizedErr)
+		logAPIError(log, "CallMcpTool", s.SessionID, start, errors.New(sanitizedErr))
 		return &models.McpToolResult{
 			Success:      false,
 			Data:         "",
 			ErrorMessage: sanitizedErr,
 			RequestID:    "",
-		}, nil
+		}, &ToolError{Tool: toolName, Message: sanitizedErr}
 	}
 
 	// Check VPC network configuration
 	if s.NetworkInterfaceIp() == "" || s.HttpPort() == "" {
 		sanitizedErr := fmt.Sprintf("VPC network configuration incomplete: networkInterfaceIp=%s, httpPort=%s", s.NetworkInterfaceIp(), s.HttpPort())
-		fmt.Println("Error calling VPC CallMcpTool -", toolName, ":", sanitizedErr)
+		logAPIError(log, "CallMcpTool", s.SessionID, start, errors.New(sanitizedErr))
 		return &models.McpToolResult{
 			Success:      false,
 			Data:         "",
 			ErrorMessage: sanitizedErr,
 			RequestID:    "",
-		}, nil
+		}, &APIError{API: "CallMcpTool", Message: sanitizedErr}
 	}
 
 	// Construct VPC URL with query parameters
@@ -723,44 +832,59 @@ func (s *Session) callMcpToolVPC(toolName, argsJSON string) (*models.McpToolResu
 	fullURL := fmt.Sprintf("%s?%s", baseURL, params.Encode())
 
 	// Send HTTP request
-	response, err := http.Get(fullURL)
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
 	if err != nil {
-		sanitizedErr := utils.SanitizeError(err)
-		fmt.Println("Error calling VPC CallMcpTool -", toolName, ":", sanitizedErr)
 		return &models.McpToolResult{
 			Success:      false,
 			Data:         "",
 			ErrorMessage: fmt.Sprintf("VPC request failed: %v", err),
 			RequestID:    "",
-		}, nil
+		}, newAPIError("CallMcpTool", err)
+	}
+	response, err := http.DefaultClient.Do(httpRequest)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		logAPIError(log, "CallMcpTool", s.SessionID, start, err)
+		return &models.McpToolResult{
+			Success:      false,
+			Data:         "",
+			ErrorMessage: fmt.Sprintf("VPC request failed: %v", err),
+			RequestID:    "",
+		}, newAPIError("CallMcpTool", err)
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
 		sanitizedErr := fmt.Sprintf("VPC request failed with status: %d", response.StatusCode)
-		fmt.Println("Error calling VPC CallMcpTool -", toolName, ":", sanitizedErr)
+		logAPIError(log, "CallMcpTool", s.SessionID, start, errors.New(sanitizedErr))
 		return &models.McpToolResult{
 			Success:      false,
 			Data:         "",
 			ErrorMessage: sanitizedErr,
 			RequestID:    "",
-		}, nil
+		}, &APIError{
+			API:        "CallMcpTool",
+			Message:    sanitizedErr,
+			RequestID:  requestID,
+			HTTPStatus: response.StatusCode,
+		}
 	}
 
 	// Parse response
 	var responseData interface{}
 	if err := json.NewDecoder(response.Body).Decode(&responseData); err != nil {
-		sanitizedErr := utils.SanitizeError(err)
-		fmt.Println("Error calling VPC CallMcpTool -", toolName, ":", sanitizedErr)
+		logAPIError(log, "CallMcpTool", s.SessionID, start, err)
 		return &models.McpToolResult{
 			Success:      false,
 			Data:         "",
 			ErrorMessage: fmt.Sprintf("Failed to parse VPC response: %v", err),
 			RequestID:    "",
-		}, nil
+		}, &APIError{API: "CallMcpTool", Message: "failed to parse VPC response", RequestID: requestID, Err: err}
 	}
 
-	fmt.Println("Response from VPC CallMcpTool -", toolName, ":", responseData)
+	logAPIResponse(log, "CallMcpTool", s.SessionID, requestID, start, responseData)
 
 	// Extract text content from the response
 	textContent := s.extractTextContentFromResponse(responseData)
@@ -774,7 +898,7 @@ func (s *Session) callMcpToolVPC(toolName, argsJSON string) (*models.McpToolResu
 }
 
 // callMcpToolAPI handles traditional API-based MCP tool calls
-func (s *Session) callMcpToolAPI(toolName, argsJSON string) (*models.McpToolResult, error) {
+func (s *Session) callMcpToolAPI(ctx context.Context, toolName, argsJSON string) (*models.McpToolResult, error) {
 	// Helper function to convert string to *string
 	stringPtr := func(s string) *string { return &s }
 
@@ -788,24 +912,28 @@ func (s *Session) callMcpToolAPI(toolName, argsJSON string) (*models.McpToolResu
 	}
 
 	// Log API request
-	fmt.Println("API Call: CallMcpTool -", toolName)
-	fmt.Printf("Request: SessionId=%s, Args=%s\n", *callToolRequest.SessionId, *callToolRequest.Args)
+	log := s.GetLogger()
+	logAPIRequest(log, "CallMcpTool", s.SessionID, "tool", toolName, "args", argsJSON)
+
+	start := time.Now()
 
-	response, err := s.GetClient().CallMcpTool(callToolRequest)
+	response, retries, err := invokeWithRetry(ctx, s.GetRetryPolicy(), log, "CallMcpTool", func(runtime *dara.RuntimeOptions) (*mcp.CallMcpToolResponse, error) {
+		return s.GetClient().CallMcpToolWithOptions(callToolRequest, runtime)
+	})
 
 	// Log API response
 	if err != nil {
-		sanitizedErr := utils.SanitizeError(err)
-		fmt.Println("Error calling CallMcpTool -", toolName, ":", sanitizedErr)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		logAPIError(log, "CallMcpTool", s.SessionID, start, err)
 		return &models.McpToolResult{
 			Success:      false,
 			Data:         "",
 			ErrorMessage: fmt.Sprintf("API request failed: %v", err),
 			RequestID:    "",
-		}, nil
-	}
-	if response != nil && response.Body != nil {
-		fmt.Println("Response from CallMcpTool -", toolName, ":", response.Body)
+			RetryCount:   retries,
+		}, sessionError(s.SessionID, newAPIError("CallMcpTool", err))
 	}
 
 	// Extract request ID
@@ -814,14 +942,24 @@ func (s *Session) callMcpToolAPI(toolName, argsJSON string) (*models.McpToolResu
 		requestID = *response.Body.GetRequestId()
 	}
 
+	if response != nil && response.Body != nil {
+		logAPIResponse(log, "CallMcpTool", s.SessionID, requestID, start, response.Body)
+	}
+
 	// Check for API-level errors
 	if response.Body == nil || response.Body.GetData() == nil {
+		apiErr := &APIError{API: "CallMcpTool", Message: "Invalid response data format", RequestID: requestID}
+		if response.Body != nil && response.Body.Code != nil {
+			apiErr = newAPIResponseError("CallMcpTool", tea.StringValue(response.Body.Code),
+				tea.StringValue(response.Body.Message), requestID)
+		}
 		return &models.McpToolResult{
 			Success:      false,
 			Data:         "",
 			ErrorMessage: "Invalid response data format",
 			RequestID:    requestID,
-		}, nil
+			RetryCount:   retries,
+		}, sessionError(s.SessionID, apiErr)
 	}
 
 	// Parse response data
@@ -849,6 +987,7 @@ func (s *Session) callMcpToolAPI(toolName, argsJSON string) (*models.McpToolResu
 				Data:         "",
 				ErrorMessage: errorMessage,
 				RequestID:    requestID,
+				RetryCount:   retries,
 			}, nil
 		}
 	}
@@ -861,6 +1000,7 @@ func (s *Session) callMcpToolAPI(toolName, argsJSON string) (*models.McpToolResu
 		Data:         textContent,
 		ErrorMessage: "",
 		RequestID:    requestID,
+		RetryCount:   retries,
 	}, nil
 }
 
@@ -0,0 +1,162 @@
+package agentbay
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// sessionExportVersion is the version of the format written by Session.Export
+const sessionExportVersion = 1
+
+// exportedSession is the format written by Session.Export
+type exportedSession struct {
+	Version            int       `json:"version"`
+	SessionID          string    `json:"sessionId"`
+	ImageId            string    `json:"imageId,omitempty"`
+	IsVpcEnabled       bool      `json:"isVpc,omitempty"`
+	NetworkInterfaceIP string    `json:"networkInterfaceIp,omitempty"`
+	HttpPortNumber     string    `json:"httpPort,omitempty"`
+	McpTools           []McpTool `json:"mcpTools,omitempty"`
+	// Secrets holds the encrypted sessionSecrets if the session was exported with a key
+	Secrets string `json:"secrets,omitempty"`
+}
+
+// sessionSecrets are the fields of a session that grant access to it
+type sessionSecrets struct {
+	Token       string `json:"token,omitempty"`
+	ResourceUrl string `json:"resourceUrl,omitempty"`
+}
+
+// Export serializes the session so that another process can resume it with AgentBay.Import. The
+// token and the resource URL of the session grant access to it: they are left out if key is nil
+// and encrypted with AES-GCM otherwise, in which case key must be 16, 24 or 32 bytes long. The
+// API key of the client is never exported.
+func (s *Session) Export(key []byte) ([]byte, error) {
+	exported := exportedSession{
+		Version:            sessionExportVersion,
+		SessionID:          s.SessionID,
+		ImageId:            s.ImageId,
+		IsVpcEnabled:       s.IsVpcEnabled,
+		NetworkInterfaceIP: s.NetworkInterfaceIP,
+		HttpPortNumber:     s.HttpPortNumber,
+		McpTools:           s.McpTools,
+	}
+	if key != nil {
+		secrets, err := json.Marshal(sessionSecrets{Token: s.Token, ResourceUrl: s.ResourceUrl})
+		if err != nil {
+			return nil, fmt.Errorf("failed to export session %s: %w", s.SessionID, err)
+		}
+		sealed, err := sealSecrets(key, s.SessionID, secrets)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export session %s: %w", s.SessionID, err)
+		}
+		exported.Secrets = sealed
+	}
+
+	data, err := json.Marshal(exported)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export session %s: %w", s.SessionID, err)
+	}
+	return data, nil
+}
+
+// Import restores a session exported with Session.Export and stores it in the session cache. key
+// must be the key passed to Export, or nil if the session was exported without secrets.
+func (a *AgentBay) Import(blob, key []byte) (*Session, error) {
+	return a.ImportWithContext(context.Background(), blob, key)
+}
+
+// ImportWithContext restores a session like Import, honouring the cancellation and deadline of
+// ctx. A session exported with secrets is restored without calling the API. Otherwise the
+// secrets are restored with Get, which fails if the session no longer exists.
+func (a *AgentBay) ImportWithContext(ctx context.Context, blob, key []byte) (*Session, error) {
+	var exported exportedSession
+	if err := json.Unmarshal(blob, &exported); err != nil {
+		return nil, fmt.Errorf("invalid exported session: %w", err)
+	}
+	if exported.Version != sessionExportVersion {
+		return nil, fmt.Errorf("unsupported exported session version %d", exported.Version)
+	}
+	if exported.SessionID == "" {
+		return nil, errors.New("invalid exported session: no session ID")
+	}
+
+	session := NewSession(a, exported.SessionID)
+	session.ImageId = exported.ImageId
+	session.IsVpcEnabled = exported.IsVpcEnabled
+	session.NetworkInterfaceIP = exported.NetworkInterfaceIP
+	session.HttpPortNumber = exported.HttpPortNumber
+	session.McpTools = exported.McpTools
+
+	if exported.Secrets == "" {
+		// Restore the image and the MCP tools from the export and the rest from the API. The
+		// cache is only updated if the session still exists.
+		result, err := a.getWithContext(ctx, session.SessionID, session)
+		if err != nil {
+			return nil, err
+		}
+		return result.Session, nil
+	}
+
+	if key == nil {
+		return nil, fmt.Errorf("session %s was exported with secrets, a key is required to import it", exported.SessionID)
+	}
+	data, err := openSecrets(key, exported.SessionID, exported.Secrets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import session %s: %w", exported.SessionID, err)
+	}
+	var secrets sessionSecrets
+	if err := json.Unmarshal(data, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to import session %s: %w", exported.SessionID, err)
+	}
+	session.Token = secrets.Token
+	session.ResourceUrl = secrets.ResourceUrl
+
+	a.Sessions.Store(session.SessionID, *session)
+	return session, nil
+}
+
+// sealSecrets encrypts data with AES-GCM, authenticating the session ID with it, and returns the
+// nonce and the ciphertext encoded with base64
+func sealSecrets(key []byte, sessionID string, data []byte) (string, error) {
+	aead, err := newSecretsAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, data, []byte(sessionID))), nil
+}
+
+// openSecrets decrypts the result of sealSecrets
+func openSecrets(key []byte, sessionID, sealed string) ([]byte, error) {
+	aead, err := newSecretsAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil || len(data) < aead.NonceSize() {
+		return nil, errors.New("invalid secrets")
+	}
+	plain, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(sessionID))
+	if err != nil {
+		return nil, errors.New("failed to decrypt secrets, the key is wrong or the data was modified")
+	}
+	return plain, nil
+}
+
+func newSecretsAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,276 @@
+package agentbay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/logger"
+)
+
+const (
+	// LabelSessionOwner is the label with which a SessionJanitor marks the sessions it tracks
+	LabelSessionOwner = "agentbay-owner"
+	// LabelSessionExpiresAt is the label holding the Unix time, in seconds, after which a
+	// SessionJanitor releases a session that was not kept alive
+	LabelSessionExpiresAt = "agentbay-expires-at"
+)
+
+const (
+	// DefaultJanitorTTL is how long a tracked session stays alive without a keepalive by default
+	DefaultJanitorTTL = 30 * time.Minute
+	// DefaultJanitorScanInterval is how often a SessionJanitor scans for expired sessions by default
+	DefaultJanitorScanInterval = 5 * time.Minute
+)
+
+// SessionJanitorOptions configures a SessionJanitor
+type SessionJanitorOptions struct {
+	// Owner identifies the sessions of the janitor, e.g. the name of the service. Processes that
+	// share an owner release each other's expired sessions, including those of crashed processes.
+	Owner string
+	// TTL is how long a session stays alive after it was tracked or last kept alive. Defaults to
+	// DefaultJanitorTTL.
+	TTL time.Duration
+	// KeepaliveInterval is how often the expiry of tracked sessions is extended. Defaults to a
+	// third of TTL; a negative value disables the keepalive.
+	KeepaliveInterval time.Duration
+	// ScanInterval is how often expired sessions of the owner are released. Defaults to
+	// DefaultJanitorScanInterval; a negative value disables scanning.
+	ScanInterval time.Duration
+	// SyncContext synchronizes the contexts of expired sessions before they are released
+	SyncContext bool
+}
+
+// JanitorScanResult is the result of SessionJanitor.Scan
+type JanitorScanResult struct {
+	// Scanned is the number of sessions of the owner that were found
+	Scanned int
+	// Released holds the IDs of the expired sessions that were released
+	Released []string
+}
+
+// SessionJanitor releases sessions that outlive the process that created them. Tracked sessions
+// are labelled with an owner and an expiry that is extended periodically while the process runs;
+// sessions of the owner whose expiry has passed are released.
+type SessionJanitor struct {
+	agentBay *AgentBay
+	options  SessionJanitorOptions
+
+	mu   sync.Mutex
+	held map[string]*Session
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewSessionJanitor creates a janitor for the sessions of options.Owner and starts its keepalive
+// and scan loops. The janitor must be stopped with Close.
+func (a *AgentBay) NewSessionJanitor(options SessionJanitorOptions) (*SessionJanitor, error) {
+	if options.Owner == "" {
+		return nil, errors.New("session janitor owner must not be empty")
+	}
+	if options.TTL <= 0 {
+		options.TTL = DefaultJanitorTTL
+	}
+	if options.KeepaliveInterval == 0 {
+		options.KeepaliveInterval = options.TTL / 3
+	}
+	if options.ScanInterval == 0 {
+		options.ScanInterval = DefaultJanitorScanInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	janitor := &SessionJanitor{
+		agentBay: a,
+		options:  options,
+		held:     map[string]*Session{},
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	go janitor.run(ctx)
+	return janitor, nil
+}
+
+// Track labels session with the owner and expiry of the janitor, keeping its other labels, and
+// keeps it alive until it is untracked or no longer exists. The labels are read and written back
+// as a whole, so label changes made concurrently by others, including another janitor tracking
+// the same session, are lost. A session must not be relabelled while it is tracked.
+func (j *SessionJanitor) Track(ctx context.Context, session *Session) error {
+	if err := j.refresh(ctx, session); err != nil {
+		return err
+	}
+	j.mu.Lock()
+	j.held[session.SessionID] = session
+	j.mu.Unlock()
+	return nil
+}
+
+// Untrack stops keeping session alive. Its labels are left unchanged, so the session is released
+// by a scan after it expires unless it is deleted before.
+func (j *SessionJanitor) Untrack(session *Session) {
+	j.mu.Lock()
+	delete(j.held, session.SessionID)
+	j.mu.Unlock()
+}
+
+// Keepalive extends the expiry of all tracked sessions. Sessions that no longer exist are
+// untracked. It returns the errors of the sessions whose expiry could not be extended.
+func (j *SessionJanitor) Keepalive(ctx context.Context) error {
+	var errs []error
+	for _, session := range j.tracked() {
+		err := j.refresh(ctx, session)
+		if errors.Is(err, ErrSessionNotFound) {
+			j.Untrack(session)
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Scan releases the sessions of the owner whose expiry has passed. Tracked sessions and sessions
+// without a valid expiry are left alone. The result is returned together with the errors of the
+// sessions that could not be checked or released.
+func (j *SessionJanitor) Scan(ctx context.Context) (*JanitorScanResult, error) {
+	// Collect all pages first, since releasing sessions changes the pages
+	var sessionIDs []string
+	for info, err := range j.agentBay.ListAll(ctx, map[string]string{LabelSessionOwner: j.options.Owner}) {
+		if err != nil {
+			return nil, err
+		}
+		sessionIDs = append(sessionIDs, info.SessionId)
+	}
+
+	result := &JanitorScanResult{Scanned: len(sessionIDs)}
+	now := time.Now()
+	var errs []error
+	for _, sessionID := range sessionIDs {
+		if j.isTracked(sessionID) {
+			continue
+		}
+		session := NewSession(j.agentBay, sessionID)
+		labels, err := sessionLabels(ctx, session)
+		if errors.Is(err, ErrSessionNotFound) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if labels[LabelSessionOwner] != j.options.Owner {
+			continue
+		}
+		expiresAt, err := strconv.ParseInt(labels[LabelSessionExpiresAt], 10, 64)
+		if err != nil {
+			j.agentBay.GetLogger().Warn("Session has no valid expiry label", logger.KeySessionID, sessionID)
+			continue
+		}
+		if now.Before(time.Unix(expiresAt, 0)) {
+			continue
+		}
+
+		_, err = j.agentBay.DeleteWithContext(ctx, session, j.options.SyncContext)
+		if err != nil && !errors.Is(err, ErrSessionNotFound) {
+			errs = append(errs, err)
+			continue
+		}
+		j.agentBay.GetLogger().Info("Released expired session", logger.KeySessionID, sessionID)
+		result.Released = append(result.Released, sessionID)
+	}
+	return result, errors.Join(errs...)
+}
+
+// Close stops the keepalive and scan loops. Tracked sessions are not deleted; they are released
+// by a later scan once they expire.
+func (j *SessionJanitor) Close() {
+	j.cancel()
+	<-j.done
+}
+
+// run keeps tracked sessions alive and scans for expired sessions until ctx is done
+func (j *SessionJanitor) run(ctx context.Context) {
+	defer close(j.done)
+	var keepalive, scan <-chan time.Time
+	if j.options.KeepaliveInterval > 0 {
+		ticker := time.NewTicker(j.options.KeepaliveInterval)
+		defer ticker.Stop()
+		keepalive = ticker.C
+	}
+	if j.options.ScanInterval > 0 {
+		ticker := time.NewTicker(j.options.ScanInterval)
+		defer ticker.Stop()
+		scan = ticker.C
+		// Release the sessions left behind by a previous process right away
+		j.scan(ctx)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepalive:
+			if err := j.Keepalive(ctx); err != nil && ctx.Err() == nil {
+				j.agentBay.GetLogger().Warn("Failed to keep sessions alive", logger.KeyError, err)
+			}
+		case <-scan:
+			j.scan(ctx)
+		}
+	}
+}
+
+func (j *SessionJanitor) scan(ctx context.Context) {
+	if _, err := j.Scan(ctx); err != nil && ctx.Err() == nil {
+		j.agentBay.GetLogger().Warn("Failed to release expired sessions", logger.KeyError, err)
+	}
+}
+
+// refresh sets the owner and expiry labels of session, keeping its other labels. SetLabel
+// replaces all labels, so a change made between the read and the write is overwritten.
+func (j *SessionJanitor) refresh(ctx context.Context, session *Session) error {
+	labels, err := sessionLabels(ctx, session)
+	if err != nil {
+		return err
+	}
+	labels[LabelSessionOwner] = j.options.Owner
+	labels[LabelSessionExpiresAt] = strconv.FormatInt(time.Now().Add(j.options.TTL).Unix(), 10)
+	_, err = session.SetLabelsWithContext(ctx, labels)
+	return err
+}
+
+func (j *SessionJanitor) tracked() []*Session {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	sessions := make([]*Session, 0, len(j.held))
+	for _, session := range j.held {
+		sessions = append(sessions, session)
+	}
+	return sessions
+}
+
+func (j *SessionJanitor) isTracked(sessionID string) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	_, ok := j.held[sessionID]
+	return ok
+}
+
+// sessionLabels returns the labels of session as a map
+func sessionLabels(ctx context.Context, session *Session) (map[string]string, error) {
+	result, err := session.GetLabelsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	labels := map[string]string{}
+	if result.Labels != "" {
+		if err := json.Unmarshal([]byte(result.Labels), &labels); err != nil {
+			return nil, fmt.Errorf("failed to parse labels of session %s: %w", session.SessionID, err)
+		}
+	}
+	return labels, nil
+}
@@ -0,0 +1,107 @@
+package agentbay
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"iter"
+)
+
+// DefaultListPageSize is the number of sessions ListAll and ListFrom request per page
+const DefaultListPageSize int32 = 100
+
+// SessionCursor is a position in the list of sessions matching Labels. ListFrom advances it as
+// sessions are returned, so that a listing can be resumed later, possibly in another process,
+// from the encoded cursor.
+type SessionCursor struct {
+	// Labels filters the sessions
+	Labels map[string]string `json:"labels,omitempty"`
+	// PageSize is the number of sessions per page. Defaults to DefaultListPageSize.
+	PageSize int32 `json:"pageSize,omitempty"`
+	// PageToken identifies the page being read, empty for the first page
+	PageToken string `json:"pageToken,omitempty"`
+	// Offset is the number of sessions of the page that were already returned
+	Offset int `json:"offset,omitempty"`
+	// Done reports that all sessions were returned
+	Done bool `json:"done,omitempty"`
+}
+
+// Encode returns the cursor as an opaque URL-safe string
+func (c *SessionCursor) Encode() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode session cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// ParseSessionCursor decodes a cursor returned by SessionCursor.Encode
+func ParseSessionCursor(s string) (*SessionCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session cursor: %w", err)
+	}
+	cursor := &SessionCursor{}
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, fmt.Errorf("invalid session cursor: %w", err)
+	}
+	return cursor, nil
+}
+
+// ListAll returns an iterator over all sessions matching labels. Pages are requested lazily as
+// the iteration proceeds. Only SessionId is set in the returned SessionInfo. If a page cannot
+// be listed, the error is yielded and the iteration ends.
+func (a *AgentBay) ListAll(ctx context.Context, labels map[string]string) iter.Seq2[*SessionInfo, error] {
+	return a.ListFrom(ctx, &SessionCursor{Labels: labels})
+}
+
+// ListFrom returns an iterator over the sessions after cursor, like ListAll. cursor is advanced
+// past every yielded session; after an error it still points to the first session that was not
+// yielded, so iterating again retries.
+func (a *AgentBay) ListFrom(ctx context.Context, cursor *SessionCursor) iter.Seq2[*SessionInfo, error] {
+	return func(yield func(*SessionInfo, error) bool) {
+		labels := cursor.Labels
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labelsJSON, err := json.Marshal(labels)
+		if err != nil {
+			yield(nil, fmt.Errorf("failed to marshal labels to JSON: %w", err))
+			return
+		}
+		pageSize := cursor.PageSize
+		if pageSize <= 0 {
+			pageSize = DefaultListPageSize
+		}
+
+		for !cursor.Done {
+			page, err := a.listPage(ctx, string(labelsJSON), pageSize, cursor.PageToken)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if cursor.Offset >= len(page.SessionIds) {
+				cursor.advance(page.NextToken)
+				continue
+			}
+			for i := cursor.Offset; i < len(page.SessionIds); i++ {
+				// Advance before yielding, so that the cursor is current when the caller stops
+				if i == len(page.SessionIds)-1 {
+					cursor.advance(page.NextToken)
+				} else {
+					cursor.Offset = i + 1
+				}
+				if !yield(&SessionInfo{SessionId: page.SessionIds[i]}, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// advance moves the cursor to the start of the page identified by nextToken, or to the end
+func (c *SessionCursor) advance(nextToken string) {
+	c.PageToken, c.Offset = nextToken, 0
+	c.Done = nextToken == ""
+}
@@ -2,10 +2,84 @@ package agentbay
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/browser"
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
 )
 
+// BrowserContext binds a context to the session's browser so that browser data such as cookies
+// is persisted across sessions, and optionally loads extensions from an extension context.
+type BrowserContext struct {
+	// ContextID is the ID of the context in which browser data is stored.
+	ContextID string
+
+	// AutoUpload uploads the browser data when the session ends. Defaults to true.
+	AutoUpload bool
+
+	// ExtensionOption selects the extensions to load into the browser, if any.
+	ExtensionOption *ExtensionOption
+}
+
+// NewBrowserContext creates a browser context for the given context ID with AutoUpload enabled.
+func NewBrowserContext(contextID string) *BrowserContext {
+	return &BrowserContext{
+		ContextID:  contextID,
+		AutoUpload: true,
+	}
+}
+
+// WithAutoUpload sets whether browser data is uploaded when the session ends.
+func (bc *BrowserContext) WithAutoUpload(autoUpload bool) *BrowserContext {
+	bc.AutoUpload = autoUpload
+	return bc
+}
+
+// WithExtensionOption sets the extensions to load into the browser.
+func (bc *BrowserContext) WithExtensionOption(option *ExtensionOption) *BrowserContext {
+	bc.ExtensionOption = option
+	return bc
+}
+
+// Validate checks that the browser context names a context and that its extension option,
+// if any, is valid.
+func (bc *BrowserContext) Validate() error {
+	if strings.TrimSpace(bc.ContextID) == "" {
+		return fmt.Errorf("browser context ID cannot be empty")
+	}
+	if bc.ExtensionOption != nil {
+		if err := bc.ExtensionOption.Validate(); err != nil {
+			return fmt.Errorf("invalid extension option: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetContextSyncs returns the context syncs for the browser data and, if configured, the
+// extensions.
+func (bc *BrowserContext) GetContextSyncs() []*ContextSync {
+	policy := NewSyncPolicy()
+	policy.UploadPolicy.AutoUpload = bc.AutoUpload
+	policy.BWList = &BWList{
+		WhiteLists: []*WhiteList{
+			{Path: "/Local State", ExcludePaths: []string{}},
+			{Path: "/Default/Cookies", ExcludePaths: []string{}},
+			{Path: "/Default/Cookies-journal", ExcludePaths: []string{}},
+		},
+	}
+
+	contextSyncs := []*ContextSync{{
+		ContextID: bc.ContextID,
+		Path:      browser.BrowserDataPath,
+		Policy:    policy,
+	}}
+	if bc.ExtensionOption != nil {
+		contextSyncs = append(contextSyncs, bc.ExtensionOption.ContextSync())
+	}
+	return contextSyncs
+}
+
 // CreateSessionParams provides a way to configure the parameters for creating a new session
 // in the AgentBay cloud environment.
 type CreateSessionParams struct {
@@ -19,6 +93,9 @@ type CreateSessionParams struct {
 	// These configurations define how contexts should be synchronized and mounted.
 	ContextSync []*ContextSync
 
+	// BrowserContext persists browser data and loads extensions for the session's browser.
+	BrowserContext *BrowserContext
+
 	// IsVpc specifies whether to create a VPC-based session. Defaults to false.
 	IsVpc bool
 
@@ -49,6 +126,20 @@ func (p *CreateSessionParams) WithImageId(imageId string) *CreateSessionParams {
 	return p
 }
 
+// WithBrowserContext sets the browser context for the session parameters and returns the updated parameters.
+func (p *CreateSessionParams) WithBrowserContext(browserContext *BrowserContext) *CreateSessionParams {
+	p.BrowserContext = browserContext
+	return p
+}
+
+// WithBrowserContextID persists the browser data of the session in the context with the given ID
+// and returns the updated parameters. If autoUpload is true, browser data is uploaded when the
+// session ends.
+func (p *CreateSessionParams) WithBrowserContextID(contextID string, autoUpload bool) *CreateSessionParams {
+	p.BrowserContext = NewBrowserContext(contextID).WithAutoUpload(autoUpload)
+	return p
+}
+
 // WithIsVpc sets the VPC flag for the session parameters and returns the updated parameters.
 func (p *CreateSessionParams) WithIsVpc(isVpc bool) *CreateSessionParams {
 	p.IsVpc = isVpc
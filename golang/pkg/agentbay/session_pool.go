@@ -0,0 +1,403 @@
+package agentbay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/logger"
+)
+
+// DefaultPoolHealthCheckInterval is how often a SessionPool checks its idle sessions by default
+const DefaultPoolHealthCheckInterval = time.Minute
+
+// ErrPoolClosed is returned by SessionPool.Acquire after the pool was closed
+var ErrPoolClosed = errors.New("session pool is closed")
+
+// SessionPoolOptions configures a SessionPool
+type SessionPoolOptions struct {
+	// MinIdle is the number of idle sessions the pool keeps ready. They are created in the
+	// background when the pool is created and whenever sessions are acquired.
+	MinIdle int
+	// MaxSize limits the number of idle and acquired sessions. Acquire waits for a session to
+	// be released when the limit is reached. If zero, the number is not limited.
+	MaxSize int
+	// MaxUses is the number of times a session is handed out before it is deleted instead of
+	// being reused. If zero, sessions are reused until they are too old or unhealthy.
+	MaxUses int
+	// MaxAge is the time after which a session is deleted instead of being handed out again. If
+	// zero, sessions do not expire.
+	MaxAge time.Duration
+	// HealthCheckInterval is how often idle sessions are checked with Session.Info. Sessions
+	// that fail the check are deleted. Defaults to DefaultPoolHealthCheckInterval; a negative
+	// value disables health checks.
+	HealthCheckInterval time.Duration
+	// SyncContext synchronizes the contexts of sessions before they are deleted
+	SyncContext bool
+}
+
+// SessionPoolStats is a snapshot of the sessions of a SessionPool
+type SessionPoolStats struct {
+	// Idle is the number of sessions ready to be acquired
+	Idle int
+	// Acquired is the number of sessions handed out and not yet released
+	Acquired int
+	// Creating is the number of sessions being created
+	Creating int
+}
+
+// SessionPool keeps pre-created sessions of one CreateSessionParams template, so that
+// acquiring a session does not wait for it to be created. Use one pool per template.
+type SessionPool struct {
+	agentBay *AgentBay
+	params   *CreateSessionParams
+	options  SessionPoolOptions
+
+	mu       sync.Mutex
+	idle     []*pooledSession
+	acquired map[string]*pooledSession
+	creating int
+	warming  int
+	closed   bool
+	// changed is closed and replaced whenever a session becomes idle or a slot is freed
+	changed chan struct{}
+
+	wake   chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// pooledSession is a session of a SessionPool with its usage
+type pooledSession struct {
+	session *Session
+	created time.Time
+	uses    int
+}
+
+// NewSessionPool creates a pool of sessions created with params and starts creating
+// options.MinIdle sessions in the background. params and options may be nil. The pool must be
+// closed with Close, which deletes all its sessions.
+func (a *AgentBay) NewSessionPool(params *CreateSessionParams, options *SessionPoolOptions) *SessionPool {
+	if params == nil {
+		params = NewCreateSessionParams()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := &SessionPool{
+		agentBay: a,
+		params:   params,
+		acquired: map[string]*pooledSession{},
+		changed:  make(chan struct{}),
+		wake:     make(chan struct{}, 1),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	if options != nil {
+		pool.options = *options
+	}
+	if pool.options.HealthCheckInterval == 0 {
+		pool.options.HealthCheckInterval = DefaultPoolHealthCheckInterval
+	}
+	go pool.run(ctx)
+	return pool
+}
+
+// Acquire returns an idle session, or creates one if none is idle. If MaxSize is reached, it
+// waits until a session is released or ctx is done. A creation in progress is not cancelled when
+// ctx is done; the session is kept idle for the next caller and the context error is returned.
+func (p *SessionPool) Acquire(ctx context.Context) (*Session, error) {
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return nil, ErrPoolClosed
+		}
+		for len(p.idle) > 0 {
+			pooled := p.idle[0]
+			p.idle = p.idle[1:]
+			if p.expired(pooled) {
+				p.retire(pooled)
+				continue
+			}
+			pooled.uses++
+			p.acquired[pooled.session.SessionID] = pooled
+			p.mu.Unlock()
+			p.refill()
+			return pooled.session, nil
+		}
+
+		if p.options.MaxSize <= 0 || p.size() < p.options.MaxSize {
+			p.creating++
+			p.mu.Unlock()
+			// Creation is not cancelled, which would leak a session the server already created
+			session, err := p.create(context.WithoutCancel(ctx))
+
+			p.mu.Lock()
+			p.creating--
+			if err != nil {
+				p.notify()
+				p.mu.Unlock()
+				return nil, err
+			}
+			if p.closed {
+				p.mu.Unlock()
+				p.delete(session)
+				return nil, ErrPoolClosed
+			}
+			if err := ctx.Err(); err != nil {
+				p.idle = append(p.idle, &pooledSession{session: session, created: time.Now()})
+				p.notify()
+				p.mu.Unlock()
+				return nil, err
+			}
+			p.acquired[session.SessionID] = &pooledSession{session: session, created: time.Now(), uses: 1}
+			p.mu.Unlock()
+			p.refill()
+			return session, nil
+		}
+
+		changed := p.changed
+		p.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// Release returns a session obtained from Acquire to the pool. If reuse is false, or the
+// session reached MaxUses or MaxAge, or the pool is closed, the session is deleted instead.
+func (p *SessionPool) Release(session *Session, reuse bool) error {
+	p.mu.Lock()
+	pooled, ok := p.acquired[session.SessionID]
+	if !ok {
+		p.mu.Unlock()
+		return fmt.Errorf("session %s was not acquired from this pool", session.SessionID)
+	}
+	delete(p.acquired, session.SessionID)
+	keep := reuse && !p.closed && !p.expired(pooled)
+	if keep {
+		p.idle = append(p.idle, pooled)
+	}
+	p.notify()
+	p.mu.Unlock()
+
+	if keep {
+		return nil
+	}
+	err := p.delete(session)
+	p.refill()
+	return err
+}
+
+// Stats returns the number of idle, acquired and pending sessions
+func (p *SessionPool) Stats() SessionPoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return SessionPoolStats{Idle: len(p.idle), Acquired: len(p.acquired), Creating: p.creating + p.warming}
+}
+
+// Close stops the pool and deletes all its sessions, including acquired ones. It returns the
+// errors of the sessions that could not be deleted.
+func (p *SessionPool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	sessions := make([]*Session, 0, len(p.idle)+len(p.acquired))
+	for _, pooled := range p.idle {
+		sessions = append(sessions, pooled.session)
+	}
+	for _, pooled := range p.acquired {
+		sessions = append(sessions, pooled.session)
+	}
+	p.idle, p.acquired = nil, map[string]*pooledSession{}
+	p.notify()
+	p.mu.Unlock()
+
+	p.cancel()
+	<-p.done
+	p.wg.Wait()
+
+	var errs []error
+	for _, session := range sessions {
+		if err := p.delete(session); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// run keeps MinIdle sessions ready and checks idle sessions until ctx is done
+func (p *SessionPool) run(ctx context.Context) {
+	defer close(p.done)
+	var tick <-chan time.Time
+	if p.options.HealthCheckInterval > 0 {
+		ticker := time.NewTicker(p.options.HealthCheckInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	p.warm()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.wake:
+		case <-tick:
+			p.checkIdle(ctx)
+		}
+		p.warm()
+	}
+}
+
+// refill asks run to create idle sessions if needed
+func (p *SessionPool) refill() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// warm starts creating sessions until MinIdle sessions are idle or being created
+func (p *SessionPool) warm() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for !p.closed && len(p.idle)+p.warming < p.options.MinIdle &&
+		(p.options.MaxSize <= 0 || p.size() < p.options.MaxSize) {
+		p.warming++
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			// Creation is not cancelled by Close, which would leak a session the server already
+			// created; Close waits for it and deletes it instead
+			session, err := p.create(context.Background())
+
+			p.mu.Lock()
+			p.warming--
+			if err != nil {
+				// The slot is free again for callers of Acquire waiting for MaxSize
+				p.notify()
+				p.mu.Unlock()
+				p.agentBay.GetLogger().Warn("Failed to create pooled session", logger.KeyError, err)
+				return
+			}
+			if p.closed {
+				p.mu.Unlock()
+				p.delete(session)
+				return
+			}
+			p.idle = append(p.idle, &pooledSession{session: session, created: time.Now()})
+			p.notify()
+			p.mu.Unlock()
+		}()
+	}
+}
+
+// checkIdle deletes idle sessions that are too old or fail Session.Info
+func (p *SessionPool) checkIdle(ctx context.Context) {
+	p.mu.Lock()
+	idle := append([]*pooledSession(nil), p.idle...)
+	p.mu.Unlock()
+
+	for _, pooled := range idle {
+		// Acquire may have taken the session since the snapshot and counts its uses under p.mu
+		p.mu.Lock()
+		i := p.idleIndex(pooled)
+		if i >= 0 && p.expired(pooled) {
+			p.removeAt(i)
+			i = -1
+		}
+		p.mu.Unlock()
+		if i < 0 {
+			continue
+		}
+		if _, err := pooled.session.InfoWithContext(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			p.agentBay.GetLogger().Warn("Pooled session failed health check",
+				logger.KeySessionID, pooled.session.SessionID, logger.KeyError, err)
+			p.remove(pooled)
+		}
+	}
+}
+
+// remove retires pooled if it is still idle
+func (p *SessionPool) remove(pooled *pooledSession) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if i := p.idleIndex(pooled); i >= 0 {
+		p.removeAt(i)
+	}
+}
+
+// idleIndex returns the index of pooled in p.idle, or -1 if it is not idle. p.mu must be held.
+func (p *SessionPool) idleIndex(pooled *pooledSession) int {
+	for i, candidate := range p.idle {
+		if candidate == pooled {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeAt retires the idle session at index i. p.mu must be held.
+func (p *SessionPool) removeAt(i int) {
+	pooled := p.idle[i]
+	p.idle = append(p.idle[:i], p.idle[i+1:]...)
+	p.retire(pooled)
+	p.notify()
+}
+
+// retire deletes a session that is no longer tracked in the background. p.mu must be held.
+func (p *SessionPool) retire(pooled *pooledSession) {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.delete(pooled.session)
+	}()
+}
+
+// expired reports whether pooled reached MaxUses or MaxAge
+func (p *SessionPool) expired(pooled *pooledSession) bool {
+	return (p.options.MaxUses > 0 && pooled.uses >= p.options.MaxUses) ||
+		(p.options.MaxAge > 0 && time.Since(pooled.created) >= p.options.MaxAge)
+}
+
+// size returns the number of sessions of the pool. p.mu must be held.
+func (p *SessionPool) size() int {
+	return len(p.idle) + len(p.acquired) + p.creating + p.warming
+}
+
+// notify wakes up callers of Acquire waiting for a session. p.mu must be held.
+func (p *SessionPool) notify() {
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+// create creates a session from the template. A session that was created although the
+// context synchronization wait failed is deleted.
+func (p *SessionPool) create(ctx context.Context) (*Session, error) {
+	result, err := p.agentBay.CreateWithContext(ctx, p.params)
+	if err != nil {
+		if result != nil && result.Session != nil {
+			p.delete(result.Session)
+		}
+		return nil, err
+	}
+	return result.Session, nil
+}
+
+func (p *SessionPool) delete(session *Session) error {
+	_, err := p.agentBay.DeleteWithContext(context.Background(), session, p.options.SyncContext)
+	if err != nil {
+		p.agentBay.GetLogger().Warn("Failed to delete pooled session", logger.KeySessionID, session.SessionID, logger.KeyError, err)
+	}
+	return err
+}
@@ -246,7 +246,7 @@ func TestSession_SetLabels_ValidationCases(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			result, err := session.SetLabels(tc.labels)
-			t.Logf("Running test case: %s", result)
+			t.Logf("Running test case: %+v", result)
 			if tc.expectError {
 				// Should have an error
 				t.Logf("Expecting error for test case: %s", err)
@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -89,9 +90,10 @@ func TestAgentBayGetNonExistentSession(t *testing.T) {
 	nonExistentSessionId := "session-nonexistent-12345"
 	result, err := client.Get(nonExistentSessionId)
 
-	// Get should not return error, but result.Success should be false
-	if err != nil {
-		t.Fatalf("Unexpected error: %v", err)
+	// Get should return a typed error together with a failed result
+	var apiErr *agentbay.APIError
+	if !errors.Is(err, agentbay.ErrSessionNotFound) && !errors.As(err, &apiErr) {
+		t.Fatalf("Expected a session not found or API error, got: %v", err)
 	}
 
 	if result.Success {
@@ -0,0 +1,130 @@
+package agentbay_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentBay_CreateAndDeleteBatch(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	ab := newTestAgentBay(t, api.ServeHTTP)
+
+	batch, err := ab.CreateBatch(t.Context(), nil, 5, 2)
+
+	require.NoError(t, err)
+	require.Len(t, batch.Results, 5)
+	assert.Equal(t, make([]error, 5), batch.Errors)
+	sessions := batch.Sessions()
+	assert.Len(t, sessions, 5)
+	assert.Len(t, api.live(), 5)
+
+	deleted, err := ab.DeleteBatch(sessions)
+	require.NoError(t, err)
+	for _, result := range deleted.Results {
+		assert.True(t, result.Success)
+	}
+	assert.Empty(t, api.live())
+}
+
+func TestAgentBay_CreateBatchPartialFailure(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	ab := newTestAgentBay(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("Action") == "CreateMcpSession" && api.count("CreateMcpSession") == 1 {
+			api.mu.Lock()
+			api.calls["CreateMcpSession"]++
+			api.mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"RequestId":"req-create","Success":false,"Code":"QuotaExceeded","Message":"too many sessions"}`)
+			return
+		}
+		api.ServeHTTP(w, r)
+	})
+
+	batch, err := ab.CreateBatch(t.Context(), nil, 4, 1)
+
+	assert.ErrorContains(t, err, "too many sessions")
+	assert.Nil(t, batch.Errors[0])
+	assert.Error(t, batch.Errors[1])
+	assert.Nil(t, batch.Errors[2])
+	assert.Len(t, batch.Sessions(), 3)
+	assert.Len(t, api.live(), 3)
+}
+
+func TestAgentBay_CreateBatchAbortDeletesSessions(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	ab := newTestAgentBay(t, func(w http.ResponseWriter, r *http.Request) {
+		api.ServeHTTP(w, r)
+		if api.count("CreateMcpSession") == 3 {
+			cancel()
+		}
+	})
+
+	batch, err := ab.CreateBatch(ctx, nil, 10, 1)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 3, api.count("CreateMcpSession"))
+	assert.Empty(t, api.live())
+	assert.Empty(t, batch.Sessions())
+	for i, itemErr := range batch.Errors {
+		assert.ErrorIs(t, itemErr, context.Canceled, "session %d", i)
+		assert.Nil(t, batch.Results[i], "session %d", i)
+	}
+}
+
+func TestAgentBay_CreateBatchCancelledAfterLastStartKeepsSessions(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	ab := newTestAgentBay(t, func(w http.ResponseWriter, r *http.Request) {
+		api.ServeHTTP(w, r)
+		if api.count("CreateMcpSession") == 3 {
+			cancel()
+		}
+	})
+
+	// Every creation was started before ctx was done, so the batch is not aborted
+	batch, err := ab.CreateBatch(ctx, nil, 3, 1)
+
+	require.NoError(t, err)
+	assert.Len(t, batch.Sessions(), 3)
+	assert.Len(t, api.live(), 3)
+}
+
+func TestAgentBay_BatchRateLimiter(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	ab := newTestAgentBay(t, api.ServeHTTP)
+	ab.SetRateLimiter(agentbay.NewRateLimiter(50, 2))
+
+	start := time.Now()
+	batch, err := ab.CreateBatch(t.Context(), nil, 6, 0)
+	require.NoError(t, err)
+	// Two calls are allowed at once, the other four wait 20ms each
+	assert.GreaterOrEqual(t, time.Since(start), 70*time.Millisecond)
+
+	limiter := agentbay.NewRateLimiter(1, 1)
+	require.NoError(t, limiter.Wait(t.Context()))
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+
+	// Without a positive rate, only the burst is allowed
+	for _, perSecond := range []float64{0, -1} {
+		limiter := agentbay.NewRateLimiter(perSecond, 2)
+		require.NoError(t, limiter.Wait(t.Context()))
+		require.NoError(t, limiter.Wait(t.Context()))
+		assert.Error(t, limiter.Wait(t.Context()))
+	}
+
+	_, err = ab.DeleteBatch(batch.Sessions())
+	require.NoError(t, err)
+	assert.Empty(t, api.live())
+}
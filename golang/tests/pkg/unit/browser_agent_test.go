@@ -0,0 +1,143 @@
+package agentbay_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/browser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// browserToolHandler initializes the browser and answers CallMcpTool requests with the text
+// registered for the tool, recording the arguments of each call
+func browserToolHandler(t *testing.T, texts map[string]string, toolArgs map[string]map[string]interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("Action") {
+		case "InitBrowser":
+			w.Write([]byte(`{"RequestId":"req-init","Success":true,"Data":{"Port":9222}}`))
+		case "CallMcpTool":
+			name := r.PostForm.Get("Name")
+			var args map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(r.PostForm.Get("Args")), &args))
+			toolArgs[name] = args
+
+			text, ok := texts[name]
+			body, err := json.Marshal(map[string]interface{}{
+				"RequestId": "req-" + name,
+				"Success":   true,
+				"Data": map[string]interface{}{
+					"content": []interface{}{map[string]interface{}{"type": "text", "text": text}},
+					"isError": !ok,
+				},
+			})
+			require.NoError(t, err)
+			w.Write(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func newInitializedBrowserSession(t *testing.T, texts map[string]string, toolArgs map[string]map[string]interface{}) *agentbay.Session {
+	ab := newTestAgentBay(t, browserToolHandler(t, texts, toolArgs))
+	session := agentbay.NewSession(ab, "session-123")
+	_, err := session.Browser.Initialize(nil)
+	require.NoError(t, err)
+	return session
+}
+
+func TestBrowserAgent_ActAndObserve(t *testing.T) {
+	toolArgs := map[string]map[string]interface{}{}
+	session := newInitializedBrowserSession(t, map[string]string{
+		"page_use_observe": `[{"selector":"#login","description":"Login button","method":"click","arguments":"{\"button\":\"left\"}"}]`,
+		"page_use_act":     "clicked the login button",
+	}, toolArgs)
+	agent := session.Browser.Agent
+
+	observed, err := agent.Observe(browser.ObserveOptions{Instruction: "find the login button"})
+	require.NoError(t, err)
+	require.Len(t, observed, 1)
+	assert.Equal(t, "#login", observed[0].Selector)
+	assert.Equal(t, "click", observed[0].Method)
+	assert.Equal(t, map[string]interface{}{"button": "left"}, observed[0].Arguments)
+	assert.Equal(t, "find the login button", toolArgs["page_use_observe"]["instruction"])
+	assert.Equal(t, float64(0), toolArgs["page_use_observe"]["context_id"])
+	assert.NotContains(t, toolArgs["page_use_observe"], "page_id")
+
+	timeout := 5000
+	result, err := agent.Act(browser.ActOptions{Action: "click the login button", TimeoutMs: &timeout, PageID: "target-1"})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "clicked the login button", result.Message)
+	assert.Equal(t, "req-page_use_act", result.RequestID)
+	assert.Equal(t, float64(5000), toolArgs["page_use_act"]["timeout_ms"])
+	assert.Equal(t, "target-1", toolArgs["page_use_act"]["page_id"])
+	assert.NotContains(t, toolArgs["page_use_act"], "use_vision")
+
+	result, err = agent.ActObserveResult(observed[0])
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.JSONEq(t, `{"method":"click","arguments":{"button":"left"}}`, toolArgs["page_use_act"]["action"].(string))
+}
+
+func TestBrowserAgent_ActFailureIsReportedInResult(t *testing.T) {
+	session := newInitializedBrowserSession(t, map[string]string{}, map[string]map[string]interface{}{})
+
+	result, err := session.Browser.Agent.Act(browser.ActOptions{Action: "click the missing button"})
+
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+}
+
+func TestBrowserAgent_Extract(t *testing.T) {
+	type product struct {
+		Name   string   `json:"name" description:"Product name"`
+		Price  float64  `json:"price"`
+		Tags   []string `json:"tags,omitempty"`
+		hidden string
+	}
+
+	toolArgs := map[string]map[string]interface{}{}
+	session := newInitializedBrowserSession(t, map[string]string{
+		"page_use_extract": `{"name":"Widget","price":9.5,"tags":["new"]}`,
+	}, toolArgs)
+
+	extracted, err := browser.Extract[product](session.Browser.Agent, browser.ExtractOptions{
+		Instruction: "extract the product",
+		Selector:    "#product",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &product{Name: "Widget", Price: 9.5, Tags: []string{"new"}}, extracted)
+	assert.Equal(t, "#product", toolArgs["page_use_extract"]["selector"])
+	schema, ok := strings.CutPrefix(toolArgs["page_use_extract"]["field_schema"].(string), "schema: ")
+	require.True(t, ok)
+	assert.JSONEq(t, `{
+		"title": "product",
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "description": "Product name"},
+			"price": {"type": "number"},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		},
+		"required": ["name", "price"]
+	}`, schema)
+}
+
+func TestBrowserAgent_RequiresInitializedBrowser(t *testing.T) {
+	ab := newTestAgentBay(t, jsonHandler(http.StatusOK, `{}`))
+	session := agentbay.NewSession(ab, "session-123")
+
+	_, err := session.Browser.Agent.Observe(browser.ObserveOptions{Instruction: "find links"})
+	assert.True(t, errors.Is(err, browser.ErrBrowserNotInitialized))
+
+	_, err = browser.Extract[map[string]string](session.Browser.Agent, browser.ExtractOptions{Instruction: "extract"})
+	assert.True(t, errors.Is(err, browser.ErrBrowserNotInitialized))
+}
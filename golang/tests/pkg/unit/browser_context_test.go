@@ -0,0 +1,102 @@
+package agentbay_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/browser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrowserContext_GetContextSyncs(t *testing.T) {
+	browserContext := agentbay.NewBrowserContext("ctx-browser")
+	syncs := browserContext.GetContextSyncs()
+	require.Len(t, syncs, 1)
+	assert.Equal(t, "ctx-browser", syncs[0].ContextID)
+	assert.Equal(t, browser.BrowserDataPath, syncs[0].Path)
+	assert.True(t, syncs[0].Policy.UploadPolicy.AutoUpload)
+
+	option, err := agentbay.NewExtensionOption("ctx-ext", []string{"ext_a.zip", "ext_b.zip"})
+	require.NoError(t, err)
+	syncs = browserContext.WithAutoUpload(false).WithExtensionOption(option).GetContextSyncs()
+	require.Len(t, syncs, 2)
+	assert.False(t, syncs[0].Policy.UploadPolicy.AutoUpload)
+
+	extensionSync := syncs[1]
+	assert.Equal(t, "ctx-ext", extensionSync.ContextID)
+	assert.Equal(t, browser.DefaultExtensionPath, extensionSync.Path)
+	assert.False(t, extensionSync.Policy.UploadPolicy.AutoUpload)
+	assert.True(t, extensionSync.Policy.ExtractPolicy.Extract)
+	require.Len(t, extensionSync.Policy.BWList.WhiteLists, 2)
+	assert.Equal(t, "ext_b.zip", extensionSync.Policy.BWList.WhiteLists[1].Path)
+
+	_, err = agentbay.NewExtensionOption("ctx-ext", nil)
+	assert.Error(t, err)
+}
+
+// createSessionHandler answers CreateMcpSession, recording its form values, and reports no
+// pending context synchronization
+func createSessionHandler(t *testing.T, createForm *url.Values) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("Action") {
+		case "CreateMcpSession":
+			*createForm = r.PostForm
+			w.Write([]byte(`{"RequestId":"req-create","Data":{"Success":true,"SessionId":"session-123"}}`))
+		case "GetContextInfo":
+			w.Write([]byte(`{"RequestId":"req-info","Success":true,"Data":{}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestCreate_WithBrowserContextIDSendsPersistenceData(t *testing.T) {
+	var createForm url.Values
+	ab := newTestAgentBay(t, createSessionHandler(t, &createForm))
+
+	params := agentbay.NewCreateSessionParams().
+		WithImageId("browser_latest").
+		WithBrowserContextID("ctx-browser", false)
+	params.AddContextSync("ctx-data", "/home/data", nil)
+
+	result, err := ab.Create(params)
+	require.NoError(t, err)
+	assert.Equal(t, "session-123", result.Session.SessionID)
+	assert.Len(t, params.ContextSync, 1, "params must not be modified")
+
+	var persistence []struct {
+		ContextId string `json:"ContextId"`
+		Path      string `json:"Path"`
+		Policy    string `json:"Policy"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(createForm.Get("PersistenceDataList")), &persistence))
+	require.Len(t, persistence, 2)
+	assert.Equal(t, "ctx-data", persistence[0].ContextId)
+	assert.Equal(t, "ctx-browser", persistence[1].ContextId)
+	assert.Equal(t, browser.BrowserDataPath, persistence[1].Path)
+
+	var policy agentbay.SyncPolicy
+	require.NoError(t, json.Unmarshal([]byte(persistence[1].Policy), &policy))
+	assert.False(t, policy.UploadPolicy.AutoUpload)
+	require.Len(t, policy.BWList.WhiteLists, 3)
+	assert.Equal(t, "/Default/Cookies", policy.BWList.WhiteLists[1].Path)
+}
+
+func TestCreate_RejectsInvalidBrowserContext(t *testing.T) {
+	var createForm url.Values
+	ab := newTestAgentBay(t, createSessionHandler(t, &createForm))
+
+	_, err := ab.Create(agentbay.NewCreateSessionParams().WithBrowserContextID(" ", true))
+	assert.Error(t, err)
+
+	_, err = ab.Create(agentbay.NewCreateSessionParams().WithBrowserContext(
+		agentbay.NewBrowserContext("ctx-browser").WithExtensionOption(&agentbay.ExtensionOption{ContextID: "ctx-ext"})))
+	assert.Error(t, err)
+	assert.Nil(t, createForm, "no request must be sent for invalid parameters")
+}
@@ -0,0 +1,121 @@
+package agentbay_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/browser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// browserAPIHandler answers InitBrowser and GetLink requests, recording the InitBrowser form values
+func browserAPIHandler(t *testing.T, initForm *map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("Action") {
+		case "InitBrowser":
+			*initForm = map[string]string{
+				"SessionId":      r.PostForm.Get("SessionId"),
+				"PersistentPath": r.PostForm.Get("PersistentPath"),
+				"BrowserOption":  r.PostForm.Get("BrowserOption"),
+			}
+			w.Write([]byte(`{"RequestId":"req-init","Success":true,"Data":{"Port":9222}}`))
+		case "GetLink":
+			w.Write([]byte(`{"RequestId":"req-link","Success":true,"Data":{"Url":"wss://cdp.example.com/session-123"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestBrowser_InitializeAndGetEndpointURL(t *testing.T) {
+	var initForm map[string]string
+	ab := newTestAgentBay(t, browserAPIHandler(t, &initForm))
+	session := agentbay.NewSession(ab, "session-123")
+	require.NotNil(t, session.Browser)
+
+	option := browser.NewBrowserOption()
+	option.UseStealth = true
+	option.Viewport = &browser.BrowserViewport{Width: 1280, Height: 720}
+	option.Proxies = []*browser.BrowserProxy{browser.NewWuyingProxy(browser.ProxyStrategyPolling, 5)}
+
+	ok, err := session.Browser.Initialize(option)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, session.Browser.IsInitialized())
+	assert.Equal(t, option, session.Browser.GetOption())
+
+	assert.Equal(t, "session-123", initForm["SessionId"])
+	assert.Equal(t, browser.BrowserDataPath, initForm["PersistentPath"])
+
+	var sentOption map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(initForm["BrowserOption"]), &sentOption))
+	assert.Equal(t, true, sentOption["useStealth"])
+	assert.Equal(t, map[string]interface{}{"width": float64(1280), "height": float64(720)}, sentOption["viewport"])
+	assert.Equal(t, browser.DefaultExtensionPath, sentOption["extensionPath"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"type": "wuying", "strategy": "polling", "pollsize": float64(5)}}, sentOption["proxies"])
+
+	url, err := session.Browser.GetEndpointURL()
+	require.NoError(t, err)
+	assert.Equal(t, "wss://cdp.example.com/session-123", url)
+}
+
+func TestBrowser_InitializeFailsWithoutPort(t *testing.T) {
+	ab := newTestAgentBay(t, jsonHandler(http.StatusOK,
+		`{"RequestId":"req-init","Success":false,"Code":"BrowserNotSupported","Message":"image has no browser"}`))
+	session := agentbay.NewSession(ab, "session-123")
+
+	ok, err := session.Browser.Initialize(nil)
+
+	assert.False(t, ok)
+	assert.False(t, session.Browser.IsInitialized())
+	var apiErr *agentbay.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "BrowserNotSupported", apiErr.Code)
+	assert.Equal(t, "req-init", apiErr.RequestID)
+}
+
+func TestBrowser_GetEndpointURLRequiresInitialize(t *testing.T) {
+	ab := newTestAgentBay(t, jsonHandler(http.StatusOK, `{}`))
+	session := agentbay.NewSession(ab, "session-123")
+
+	_, err := session.Browser.GetEndpointURL()
+
+	assert.True(t, errors.Is(err, browser.ErrBrowserNotInitialized))
+}
+
+func TestBrowserOption_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		option *browser.BrowserOption
+		valid  bool
+	}{
+		{"defaults", browser.NewBrowserOption(), true},
+		{"custom proxy", &browser.BrowserOption{Proxies: []*browser.BrowserProxy{browser.NewCustomProxy("127.0.0.1:9090", "", "")}}, true},
+		{"custom proxy without server", &browser.BrowserOption{Proxies: []*browser.BrowserProxy{browser.NewCustomProxy("", "", "")}}, false},
+		{"polling proxy with default pollsize", &browser.BrowserOption{Proxies: []*browser.BrowserProxy{browser.NewWuyingProxy(browser.ProxyStrategyPolling, 0)}}, true},
+		{"polling proxy with negative pollsize", &browser.BrowserOption{Proxies: []*browser.BrowserProxy{browser.NewWuyingProxy(browser.ProxyStrategyPolling, -1)}}, false},
+		{"wuying proxy without strategy", &browser.BrowserOption{Proxies: []*browser.BrowserProxy{{Type: browser.ProxyTypeWuying}}}, false},
+		{"two proxies", &browser.BrowserOption{Proxies: []*browser.BrowserProxy{
+			browser.NewWuyingProxy(browser.ProxyStrategyRestricted, 0),
+			browser.NewWuyingProxy(browser.ProxyStrategyRestricted, 0),
+		}}, false},
+		{"unknown device", &browser.BrowserOption{Fingerprint: &browser.BrowserFingerprint{Devices: []string{"tablet"}}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.option.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
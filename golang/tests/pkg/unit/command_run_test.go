@@ -0,0 +1,213 @@
+package agentbay_test
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mcp "github.com/aliyun/wuying-agentbay-sdk/golang/api/client"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/command"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// localShellSession implements the shell tool by running commands with the local bash, which
+// exercises the scripts generated by Run and Stream. It may be called concurrently.
+type localShellSession struct {
+	t     *testing.T
+	calls atomic.Int64
+}
+
+func (s *localShellSession) GetAPIKey() string                    { return "test-api-key" }
+func (s *localShellSession) GetClient() *mcp.Client               { return nil }
+func (s *localShellSession) GetSessionId() string                 { return "session-123" }
+func (s *localShellSession) IsVpc() bool                          { return false }
+func (s *localShellSession) NetworkInterfaceIp() string           { return "" }
+func (s *localShellSession) HttpPort() string                     { return "" }
+func (s *localShellSession) FindServerForTool(tool string) string { return "" }
+
+func (s *localShellSession) CallMcpTool(toolName string, args interface{}) (*models.McpToolResult, error) {
+	s.calls.Add(1)
+	require.Equal(s.t, "shell", toolName)
+	argMap := args.(map[string]interface{})
+	timeout := time.Duration(argMap["timeout_ms"].(int)) * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	shell := exec.CommandContext(ctx, "bash", "-c", argMap["command"].(string))
+	shell.WaitDelay = 100 * time.Millisecond
+	output, err := shell.Output()
+	if ctx.Err() != nil {
+		return &models.McpToolResult{Success: false, ErrorMessage: "command timed out", RequestID: "req-shell"}, nil
+	}
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, err
+	}
+	return &models.McpToolResult{Success: true, Data: string(output), RequestID: "req-shell"}, nil
+}
+
+func requireLocalShell(t *testing.T) {
+	for _, tool := range []string{"bash", "base64", "tail"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s is not available", tool)
+		}
+	}
+}
+
+func TestCommand_RunSeparatesOutputAndExitCode(t *testing.T) {
+	requireLocalShell(t)
+	cmd := command.NewCommand(&localShellSession{t: t})
+
+	result, err := cmd.Run(context.Background(), command.CommandOptions{
+		Command: "echo \"hello $GREETING\"; pwd; echo oops >&2; exit 3",
+		Cwd:     "/tmp",
+		Env:     map[string]string{"GREETING": "it's me"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.ExitCode)
+	assert.Equal(t, "hello it's me\n/tmp\n", result.Stdout)
+	assert.Equal(t, result.Stdout, result.Output)
+	assert.Equal(t, "oops\n", result.Stderr)
+	assert.Equal(t, "req-shell", result.RequestID)
+	assert.Greater(t, result.Duration, time.Duration(0))
+}
+
+func TestCommand_RunValidatesOptions(t *testing.T) {
+	cmd := command.NewCommand(&localShellSession{t: t})
+
+	_, err := cmd.Run(context.Background(), command.CommandOptions{Command: "  "})
+	assert.Error(t, err)
+
+	_, err = cmd.Run(context.Background(), command.CommandOptions{Command: "env", Env: map[string]string{"BAD-NAME": "x"}})
+	var cmdErr *agentbay.CommandError
+	assert.True(t, errors.As(err, &cmdErr))
+}
+
+func TestCommand_RunTimeout(t *testing.T) {
+	requireLocalShell(t)
+	cmd := command.NewCommand(&localShellSession{t: t})
+
+	_, err := cmd.Run(context.Background(), command.CommandOptions{Command: "sleep 5", Timeout: 100 * time.Millisecond})
+
+	var timeoutErr *agentbay.TimeoutError
+	require.True(t, errors.As(err, &timeoutErr))
+	assert.Equal(t, 100*time.Millisecond, timeoutErr.Duration)
+}
+
+func TestCommand_StreamDeliversIncrementalOutput(t *testing.T) {
+	requireLocalShell(t)
+	session := &localShellSession{t: t}
+	cmd := command.NewCommand(session)
+
+	stream, err := cmd.Stream(context.Background(), command.CommandOptions{
+		Command:      "for i in 1 2 3; do echo line $i; sleep 0.1; done; echo warn >&2; exit 2",
+		PollInterval: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	var stdout, stderr strings.Builder
+	chunkCount := 0
+	for chunk := range stream.Chunks {
+		chunkCount++
+		if chunk.Stream == command.StreamStdout {
+			stdout.WriteString(chunk.Data)
+		} else {
+			stderr.WriteString(chunk.Data)
+		}
+	}
+	result, err := stream.Wait()
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.ExitCode)
+	assert.Equal(t, "line 1\nline 2\nline 3\n", stdout.String())
+	assert.Equal(t, "warn\n", stderr.String())
+	assert.Equal(t, stdout.String(), result.Stdout)
+	assert.Equal(t, stderr.String(), result.Stderr)
+	assert.Greater(t, chunkCount, 2, "output should arrive in several chunks")
+}
+
+func TestCommand_StreamCancelKillsCommand(t *testing.T) {
+	requireLocalShell(t)
+	cmd := command.NewCommand(&localShellSession{t: t})
+	marker := "/tmp/agentbay_stream_test_" + time.Now().Format("150405.000000")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := cmd.Stream(ctx, command.CommandOptions{
+		Command:      "sleep 0.5; touch " + marker,
+		PollInterval: 20 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	cancel()
+
+	_, err = stream.Wait()
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	time.Sleep(700 * time.Millisecond)
+	assert.NoFileExists(t, marker, "the command should have been killed")
+}
+
+func TestCommand_StartProcessLifecycle(t *testing.T) {
+	requireLocalShell(t)
+	cmd := command.NewCommand(&localShellSession{t: t})
+	ctx := context.Background()
+
+	process, err := cmd.Start(ctx, command.CommandOptions{
+		Command:      "echo started; echo problem >&2; sleep 0.3; echo done; exit 4",
+		PollInterval: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { process.Remove(context.Background()) })
+	assert.Greater(t, process.PID, 0)
+
+	_, err = process.ExitCode(ctx)
+	assert.True(t, errors.Is(err, command.ErrProcessRunning))
+
+	status, err := process.Wait(ctx)
+	require.NoError(t, err)
+	assert.False(t, status.Running)
+	assert.Equal(t, 4, status.ExitCode)
+
+	logs, offset, err := process.ReadLogs(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "started\nproblem\ndone\n", logs)
+	assert.Equal(t, int64(len(logs)), offset)
+
+	logs, _, err = process.ReadLogs(ctx, int64(len("started\n")))
+	require.NoError(t, err)
+	assert.Equal(t, "problem\ndone\n", logs)
+
+	exitCode, err := process.ExitCode(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, exitCode)
+}
+
+func TestCommand_StartProcessKill(t *testing.T) {
+	requireLocalShell(t)
+	cmd := command.NewCommand(&localShellSession{t: t})
+	ctx := context.Background()
+
+	process, err := cmd.Start(ctx, command.CommandOptions{Command: "sleep 30", PollInterval: 20 * time.Millisecond})
+	require.NoError(t, err)
+	t.Cleanup(func() { process.Remove(context.Background()) })
+
+	status, err := process.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, status.Running)
+
+	assert.Error(t, process.Kill(ctx, command.Signal("STOPALL")))
+	require.NoError(t, process.Kill(ctx, command.SignalKill))
+
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	status, err = process.Wait(waitCtx)
+	require.NoError(t, err)
+	assert.Equal(t, -1, status.ExitCode)
+}
@@ -0,0 +1,156 @@
+package agentbay_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	openapiutil "github.com/alibabacloud-go/darabonba-openapi/v2/utils"
+	"github.com/alibabacloud-go/tea/tea"
+	mcp "github.com/aliyun/wuying-agentbay-sdk/golang/api/client"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/agent"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/filesystem"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAgentBay creates an AgentBay client whose API calls are served by handler
+func newTestAgentBay(t *testing.T, handler http.HandlerFunc) *agentbay.AgentBay {
+	t.Helper()
+	return newTestAgentBayWithTimeout(t, handler, 5000)
+}
+
+// newTestAgentBayWithTimeout is like newTestAgentBay with the given read and connect timeouts
+// in milliseconds
+func newTestAgentBayWithTimeout(t *testing.T, handler http.HandlerFunc, timeoutMs int) *agentbay.AgentBay {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := mcp.NewClient(&openapiutil.Config{
+		RegionId:       tea.String(""),
+		Endpoint:       tea.String(strings.TrimPrefix(server.URL, "http://")),
+		Protocol:       tea.String("HTTP"),
+		ReadTimeout:    tea.Int(timeoutMs),
+		ConnectTimeout: tea.Int(timeoutMs),
+		HttpClient:     agentbay.NewHTTPClient(timeoutMs),
+	})
+	require.NoError(t, err)
+
+	ab := &agentbay.AgentBay{
+		APIKey: "test-api-key",
+		Client: client,
+	}
+	ab.Context = &agentbay.ContextService{AgentBay: ab}
+	return ab
+}
+
+// blockingHandler never answers until the client goes away or the test ends
+func blockingHandler(release <-chan struct{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}
+}
+
+func TestAgentBay_CreateWithContext_DeadlineExceeded(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	ab := newTestAgentBay(t, blockingHandler(release))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	result, err := ab.CreateWithContext(ctx, agentbay.NewCreateSessionParams())
+
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "unexpected error: %v", err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestSession_CallMcpToolWithContext_Cancelled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	ab := newTestAgentBay(t, blockingHandler(release))
+	session := agentbay.NewSession(ab, "session-123")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	result, err := session.CallMcpToolWithContext(ctx, "shell", map[string]interface{}{"command": "sleep 60"})
+
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, context.Canceled), "unexpected error: %v", err)
+}
+
+func TestContextManager_SyncWithCallbackContext_AlreadyCancelled(t *testing.T) {
+	ab := newTestAgentBay(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected for a cancelled context")
+	})
+	session := agentbay.NewSession(ab, "session-123")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := session.Context.SyncWithCallbackContext(ctx, "", "", "", nil, 150, 1500)
+
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestFileSystem_ReadFileWithContext_Cancelled(t *testing.T) {
+	mockSession := &MockWatchSession{}
+	fs := filesystem.NewFileSystem(mockSession)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := fs.ReadFileWithContext(ctx, "/tmp/file.txt")
+
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, context.Canceled))
+	mockSession.AssertNotCalled(t, "CallMcpTool")
+}
+
+// agentTestSession implements agent.McpSession with scripted tool results
+type agentTestSession struct {
+	results map[string]*models.McpToolResult
+}
+
+func (s *agentTestSession) GetAPIKey() string    { return "test-api-key" }
+func (s *agentTestSession) GetSessionId() string { return "session-123" }
+func (s *agentTestSession) CallMcpTool(toolName string, args interface{}) (*models.McpToolResult, error) {
+	return s.results[toolName], nil
+}
+
+func TestAgent_ExecuteTaskWithContext_CancelledWhilePolling(t *testing.T) {
+	session := &agentTestSession{results: map[string]*models.McpToolResult{
+		"flux_execute_task":    {Success: true, Data: `{"task_id":"task-1"}`, RequestID: "req-1"},
+		"flux_get_task_status": {Success: true, Data: `{"status":"running"}`, RequestID: "req-2"},
+	}}
+	a := agent.NewAgent(session)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	result := a.ExecuteTaskWithContext(ctx, "open the calculator", 10)
+
+	assert.False(t, result.Success)
+	assert.Equal(t, "cancelled", result.TaskStatus)
+	assert.Equal(t, "task-1", result.TaskID)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
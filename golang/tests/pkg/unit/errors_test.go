@@ -0,0 +1,135 @@
+package agentbay_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"testing"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/command"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/filesystem"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonHandler answers every API request with the given status code and JSON body
+func jsonHandler(status int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}
+}
+
+func TestAPIError_Authentication(t *testing.T) {
+	ab := newTestAgentBay(t, jsonHandler(http.StatusUnauthorized,
+		`{"Code":"InvalidApiKey","Message":"invalid api key","RequestId":"req-401"}`))
+	session := agentbay.NewSession(ab, "session-123")
+
+	_, err := session.GetLabels()
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, agentbay.ErrAuthentication))
+
+	var apiErr *agentbay.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "GetLabel", apiErr.API)
+	assert.Equal(t, "InvalidApiKey", apiErr.Code)
+	assert.Equal(t, "invalid api key", apiErr.Message)
+	assert.Equal(t, "req-401", apiErr.RequestID)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.HTTPStatus)
+}
+
+func TestSession_CallMcpTool_ReturnsErrorOnHTTPFailure(t *testing.T) {
+	ab := newTestAgentBay(t, jsonHandler(http.StatusInternalServerError,
+		`{"Code":"InternalError","Message":"backend unavailable","RequestId":"req-500"}`))
+	session := agentbay.NewSession(ab, "session-123")
+
+	result, err := session.CallMcpTool("shell", map[string]interface{}{"command": "ls"})
+
+	require.Error(t, err)
+	require.NotNil(t, result)
+	assert.False(t, result.Success)
+	assert.False(t, errors.Is(err, agentbay.ErrAuthentication))
+
+	var apiErr *agentbay.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusInternalServerError, apiErr.HTTPStatus)
+	assert.Equal(t, "req-500", apiErr.RequestID)
+}
+
+func TestAgentBay_Get_SessionNotFound(t *testing.T) {
+	ab := newTestAgentBay(t, jsonHandler(http.StatusOK,
+		`{"Success":false,"Code":"InvalidMcpSession.NotFound","Message":"session does not exist","RequestId":"req-404"}`))
+
+	result, err := ab.Get("session-missing")
+
+	require.NotNil(t, result)
+	assert.False(t, result.Success)
+	assert.True(t, errors.Is(err, agentbay.ErrSessionNotFound))
+
+	var notFound *agentbay.SessionNotFoundError
+	require.True(t, errors.As(err, &notFound))
+	assert.Equal(t, "session-missing", notFound.SessionID)
+	assert.Equal(t, "req-404", notFound.RequestID)
+}
+
+func TestAgentBay_List_NullBody(t *testing.T) {
+	ab := newTestAgentBay(t, jsonHandler(http.StatusOK, `null`))
+
+	_, err := ab.List(nil, nil, nil)
+	assert.ErrorContains(t, err, "failed to list sessions")
+	assert.ErrorContains(t, err, "Unknown error")
+
+	page := 2
+	_, err = ab.List(nil, &page, nil)
+	assert.ErrorContains(t, err, "cannot reach page 2")
+	assert.ErrorContains(t, err, "Unknown error")
+}
+
+func TestFileSystem_FileError_NotExist(t *testing.T) {
+	mockSession := &MockWatchSession{}
+	mockSession.On("CallMcpTool", "get_file_info", map[string]string{"path": "/tmp/missing.txt"}).Return(&models.McpToolResult{
+		Success:      false,
+		ErrorMessage: "stat /tmp/missing.txt: No such file or directory",
+		RequestID:    "req-1",
+	}, nil)
+	fileSystem := filesystem.NewFileSystem(mockSession)
+
+	_, err := fileSystem.GetFileInfo("/tmp/missing.txt")
+
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+
+	var fileErr *agentbay.FileError
+	require.True(t, errors.As(err, &fileErr))
+	assert.Equal(t, "get file info", fileErr.Op)
+	assert.Equal(t, "/tmp/missing.txt", fileErr.Path)
+
+	var toolErr *agentbay.ToolError
+	require.True(t, errors.As(err, &toolErr))
+	assert.Equal(t, "get_file_info", toolErr.Tool)
+	assert.Equal(t, "req-1", toolErr.RequestID)
+}
+
+func TestCommand_CommandError_Timeout(t *testing.T) {
+	mockSession := &MockWatchSession{}
+	mockSession.On("CallMcpTool", "shell", map[string]interface{}{"command": "sleep 10", "timeout_ms": 500}).Return(&models.McpToolResult{
+		Success:      false,
+		ErrorMessage: "command timed out",
+	}, nil)
+	cmd := command.NewCommand(mockSession)
+
+	_, err := cmd.ExecuteCommand("sleep 10", 500)
+
+	var cmdErr *agentbay.CommandError
+	require.True(t, errors.As(err, &cmdErr))
+	assert.Equal(t, "sleep 10", cmdErr.Command)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	var timeoutErr *agentbay.TimeoutError
+	require.True(t, errors.As(err, &timeoutErr))
+	assert.Equal(t, "command", timeoutErr.Op)
+}
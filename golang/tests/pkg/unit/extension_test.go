@@ -0,0 +1,108 @@
+package agentbay_test
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// extensionStore emulates an extension context: presigned URLs point back at the test server,
+// and uploaded files are kept in memory by path
+type extensionStore struct {
+	t     *testing.T
+	files map[string]string
+}
+
+func (s *extensionStore) handler(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPut {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(s.t, err)
+		s.files[strings.TrimPrefix(r.URL.Path, "/upload")] = string(body)
+		return
+	}
+
+	require.NoError(s.t, r.ParseForm())
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Query().Get("Action") {
+	case "GetContext":
+		w.Write([]byte(`{"RequestId":"req-ctx","Success":true,"Data":{"Id":"ctx-ext","Name":"` + r.PostForm.Get("Name") + `"}}`))
+	case "GetContextFileUploadUrl":
+		w.Write([]byte(`{"RequestId":"req-url","Success":true,"Data":{"Url":"http://` + r.Host + `/upload` + r.PostForm.Get("FilePath") + `"}}`))
+	case "DescribeContextFiles":
+		var entries []string
+		for path := range s.files {
+			entries = append(entries, `{"FileName":"`+filepath.Base(path)+`","FilePath":"`+path+`","GmtCreate":"2025-01-01T00:00:00Z"}`)
+		}
+		w.Write([]byte(`{"RequestId":"req-list","Success":true,"Data":[` + strings.Join(entries, ",") + `]}`))
+	case "DeleteContextFile":
+		delete(s.files, r.PostForm.Get("FilePath"))
+		w.Write([]byte(`{"RequestId":"req-del","Success":true}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func writeExtensionZip(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestExtensionsService_Lifecycle(t *testing.T) {
+	store := &extensionStore{t: t, files: map[string]string{}}
+	ab := newTestAgentBay(t, store.handler)
+
+	service, err := agentbay.NewExtensionsService(ab, "my-extensions")
+	require.NoError(t, err)
+	assert.Equal(t, "ctx-ext", service.ContextID)
+	assert.Equal(t, "my-extensions", service.ContextName)
+
+	extension, err := service.Create(writeExtensionZip(t, "adblock.zip", "v1"))
+	require.NoError(t, err)
+	assert.Regexp(t, `^ext_[0-9a-f]{32}\.zip$`, extension.ID)
+	assert.Equal(t, "adblock.zip", extension.Name)
+	assert.Equal(t, "v1", store.files[agentbay.ExtensionsBasePath+"/"+extension.ID])
+
+	extensions, err := service.List()
+	require.NoError(t, err)
+	require.Len(t, extensions, 1)
+	assert.Equal(t, extension.ID, extensions[0].ID)
+
+	_, err = service.Update(extension.ID, writeExtensionZip(t, "adblock-v2.zip", "v2"))
+	require.NoError(t, err)
+	assert.Equal(t, "v2", store.files[agentbay.ExtensionsBasePath+"/"+extension.ID])
+
+	_, err = service.Update("ext_missing.zip", writeExtensionZip(t, "other.zip", "v1"))
+	assert.Error(t, err)
+
+	option, err := service.CreateExtensionOption([]string{extension.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "ctx-ext", option.ContextID)
+
+	require.NoError(t, service.Delete(extension.ID))
+	assert.Empty(t, store.files)
+}
+
+func TestExtensionsService_CreateValidatesFile(t *testing.T) {
+	store := &extensionStore{t: t, files: map[string]string{}}
+	service, err := agentbay.NewExtensionsService(newTestAgentBay(t, store.handler), "my-extensions")
+	require.NoError(t, err)
+
+	_, err = service.Create(filepath.Join(t.TempDir(), "missing.zip"))
+	var fileErr *agentbay.FileError
+	require.True(t, errors.As(err, &fileErr))
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+
+	_, err = service.Create(writeExtensionZip(t, "extension.crx", "crx"))
+	assert.Error(t, err)
+	assert.Empty(t, store.files)
+}
@@ -0,0 +1,152 @@
+package agentbay_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/filesystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requireLocalArchiveTools skips the test if the tools for format are missing
+func requireLocalArchiveTools(t *testing.T, format filesystem.ArchiveFormat) {
+	requireLocalSyncTools(t)
+	if format == filesystem.ArchiveZip {
+		for _, tool := range []string{"zip", "unzip"} {
+			if _, err := exec.LookPath(tool); err != nil {
+				t.Skipf("%s is not available", tool)
+			}
+		}
+	}
+}
+
+// readTree returns the contents of the regular files below dir by slash separated relative path
+func readTree(t *testing.T, dir string) map[string]string {
+	files := map[string]string{}
+	require.NoError(t, filepath.WalkDir(dir, func(name string, d os.DirEntry, err error) error {
+		require.NoError(t, err)
+		if d.Type().IsRegular() {
+			data, err := os.ReadFile(name)
+			require.NoError(t, err)
+			rel, _ := filepath.Rel(dir, name)
+			files[filepath.ToSlash(rel)] = string(data)
+		}
+		return nil
+	}))
+	return files
+}
+
+func TestFileSystem_PackUnpack(t *testing.T) {
+	for _, format := range []filesystem.ArchiveFormat{filesystem.ArchiveTarGz, filesystem.ArchiveZip} {
+		t.Run(string(format), func(t *testing.T) {
+			requireLocalArchiveTools(t, format)
+			fs := filesystem.NewFileSystem(newLocalFileSession(t))
+			src, other, out := t.TempDir(), t.TempDir(), t.TempDir()
+			writeTree(t, src, map[string]string{"project/main.go": "package main", "project/it's.txt": "quoted"})
+			writeTree(t, other, map[string]string{"notes.md": "# notes"})
+			archivePath := filepath.Join(out, "bundle.archive")
+
+			result, err := fs.Pack([]string{filepath.Join(src, "project"), filepath.Join(other, "notes.md")}, archivePath, format)
+			require.NoError(t, err)
+			assert.True(t, result.Success)
+
+			dest := filepath.Join(out, "extracted")
+			// Unpack derives the format from the file name
+			_, err = fs.Unpack(archivePath, dest, nil)
+			assert.ErrorContains(t, err, "archive format")
+			renamed := filepath.Join(out, "bundle."+string(format))
+			require.NoError(t, os.Rename(archivePath, renamed))
+			_, err = fs.Unpack(renamed, dest, nil)
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{
+				"project/main.go":  "package main",
+				"project/it's.txt": "quoted",
+				"notes.md":         "# notes",
+			}, readTree(t, dest))
+			assert.FileExists(t, renamed)
+		})
+	}
+}
+
+func TestFileSystem_UnpackErrors(t *testing.T) {
+	requireLocalSyncTools(t)
+	fs := filesystem.NewFileSystem(newLocalFileSession(t))
+	dir := t.TempDir()
+
+	_, err := fs.Unpack(filepath.Join(dir, "missing.tar.gz"), filepath.Join(dir, "out"), nil)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = fs.Unpack(filepath.Join(dir, "archive.rar"), dir, nil)
+	assert.ErrorContains(t, err, "archive format")
+
+	_, err = fs.Pack([]string{dir}, filepath.Join(dir, "a.tar.gz"), "rar")
+	assert.ErrorContains(t, err, `unsupported archive format "rar"`)
+}
+
+func TestFileSystem_UploadArchive(t *testing.T) {
+	for _, format := range []filesystem.ArchiveFormat{filesystem.ArchiveTarGz, filesystem.ArchiveZip} {
+		t.Run(string(format), func(t *testing.T) {
+			requireLocalArchiveTools(t, format)
+			session := newLocalFileSession(t)
+			fs := filesystem.NewFileSystem(session)
+			local, remote := t.TempDir(), t.TempDir()
+			files := map[string]string{"a.txt": "a", "sub/b.bin": "\x00\xff binary"}
+			writeTree(t, local, files)
+
+			archivePath := filepath.Join(remote, "site."+string(format))
+			result, err := fs.UploadArchive(t.Context(), local, archivePath, agentbay.NewExtractPolicy())
+			require.NoError(t, err)
+			assert.Equal(t, 2, result.Files)
+			assert.Equal(t, filepath.Join(remote, "site"), result.Destination)
+			assert.Equal(t, files, readTree(t, result.Destination))
+			assert.NoFileExists(t, archivePath)
+			assert.Equal(t, 1, countTools(session.tools, "write_file"))
+
+			result, err = fs.UploadArchive(t.Context(), local, archivePath, &filesystem.ExtractPolicy{Extract: true, ExtractToCurrentFolder: true})
+			require.NoError(t, err)
+			assert.Equal(t, remote, result.Destination)
+			assert.Equal(t, "a", readTree(t, remote)["a.txt"])
+			assert.FileExists(t, archivePath)
+
+			result, err = fs.UploadArchive(t.Context(), local, archivePath, nil)
+			require.NoError(t, err)
+			assert.Empty(t, result.Destination)
+			info, err := os.Stat(archivePath)
+			require.NoError(t, err)
+			assert.Equal(t, result.Bytes, info.Size())
+		})
+	}
+}
+
+func TestWriteArchive_Zip(t *testing.T) {
+	local := t.TempDir()
+	writeTree(t, local, map[string]string{"x/y.txt": "y", "z.txt": "z"})
+
+	var buf bytes.Buffer
+	require.NoError(t, filesystem.WriteArchive(&buf, local, filesystem.ArchiveZip))
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	var names []string
+	for _, file := range reader.File {
+		names = append(names, file.Name)
+		rc, err := file.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Base(file.Name), string(data)+".txt")
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"x/y.txt", "z.txt"}, names)
+
+	assert.Error(t, filesystem.WriteArchive(&buf, local, "rar"))
+}
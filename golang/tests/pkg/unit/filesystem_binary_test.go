@@ -0,0 +1,195 @@
+package agentbay_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/filesystem"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// localFileSession implements the filesystem tools on the local disk, with the same semantics
+// as the session's tools: read_file and write_file treat content as text
+type localFileSession struct {
+	localShellSession
+	mu    sync.Mutex
+	tools []string
+}
+
+func newLocalFileSession(t *testing.T) *localFileSession {
+	return &localFileSession{localShellSession: localShellSession{t: t}}
+}
+
+func (s *localFileSession) CallMcpTool(toolName string, args interface{}) (*models.McpToolResult, error) {
+	s.mu.Lock()
+	s.tools = append(s.tools, toolName)
+	s.mu.Unlock()
+	if toolName == "shell" {
+		return s.localShellSession.CallMcpTool(toolName, args)
+	}
+	// Tools receive their arguments as JSON
+	encoded, err := json.Marshal(args)
+	require.NoError(s.t, err)
+	var argMap map[string]interface{}
+	require.NoError(s.t, json.Unmarshal(encoded, &argMap))
+
+	switch toolName {
+	case "write_file":
+		path := argMap["path"].(string)
+		flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+		if argMap["mode"] == "append" {
+			flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		}
+		file, err := os.OpenFile(path, flags, 0o644)
+		if err != nil {
+			return &models.McpToolResult{Success: false, ErrorMessage: err.Error(), RequestID: "req-write"}, nil
+		}
+		defer file.Close()
+		_, err = file.WriteString(argMap["content"].(string))
+		require.NoError(s.t, err)
+		return &models.McpToolResult{Success: true, RequestID: "req-write"}, nil
+	case "read_file":
+		data, err := os.ReadFile(argMap["path"].(string))
+		if err != nil {
+			return &models.McpToolResult{Success: false, ErrorMessage: err.Error(), RequestID: "req-read"}, nil
+		}
+		offset, _ := argMap["offset"].(float64)
+		start, end := int(offset), len(data)
+		if length, ok := argMap["length"].(float64); ok && start+int(length) < end {
+			end = start + int(length)
+		}
+		// The tool returns text, which replaces bytes that are not valid UTF-8
+		return &models.McpToolResult{Success: true, Data: strings.ToValidUTF8(string(data[start:end]), "�"), RequestID: "req-read"}, nil
+	case "get_file_info":
+		info, err := os.Stat(argMap["path"].(string))
+		if err != nil {
+			return &models.McpToolResult{Success: false, ErrorMessage: err.Error(), RequestID: "req-info"}, nil
+		}
+		return &models.McpToolResult{
+			Success:   true,
+			Data:      fmt.Sprintf("size: %d\nisDirectory: %t", info.Size(), info.IsDir()),
+			RequestID: "req-info",
+		}, nil
+	}
+	s.t.Fatalf("unexpected tool %s", toolName)
+	return nil, nil
+}
+
+func requireLocalFileTools(t *testing.T) {
+	requireLocalShell(t)
+	for _, tool := range []string{"head", "wc", "sha256sum"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s is not available", tool)
+		}
+	}
+}
+
+// binaryPayload returns n bytes covering every byte value, including invalid UTF-8 sequences
+func binaryPayload(n int) []byte {
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte(i*7 + i/256)
+	}
+	return data
+}
+
+func TestFileSystem_WriteAndReadFileBytesRoundTrip(t *testing.T) {
+	requireLocalFileTools(t)
+	session := newLocalFileSession(t)
+	fs := filesystem.NewFileSystem(session)
+	path := filepath.Join(t.TempDir(), "it's binary.bin")
+	data := binaryPayload(2*filesystem.BinaryChunkSize + 123)
+
+	writeResult, err := fs.WriteFileBytes(path, data)
+	require.NoError(t, err)
+	assert.True(t, writeResult.Success)
+	assert.Equal(t, "req-shell", writeResult.RequestID)
+
+	local, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(data, local))
+	leftovers, err := filepath.Glob(path + ".agentbay-*")
+	require.NoError(t, err)
+	assert.Empty(t, leftovers)
+
+	readResult, err := fs.ReadFileBytes(path)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(data, readResult.Content))
+	sum := sha256.Sum256(data)
+	assert.Equal(t, hex.EncodeToString(sum[:]), readResult.SHA256)
+}
+
+func TestFileSystem_FileBytesEmptyFile(t *testing.T) {
+	requireLocalFileTools(t)
+	session := newLocalFileSession(t)
+	fs := filesystem.NewFileSystem(session)
+	path := filepath.Join(t.TempDir(), "empty")
+	require.NoError(t, os.WriteFile(path, []byte("previous contents"), 0o644))
+
+	_, err := fs.WriteFileBytes(path, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"shell"}, session.tools)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+
+	readResult, err := fs.ReadFileBytes(path)
+	require.NoError(t, err)
+	assert.Empty(t, readResult.Content)
+
+	textResult, err := fs.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "", textResult.Content)
+}
+
+func TestFileSystem_ReadFileBytesMissingFile(t *testing.T) {
+	requireLocalFileTools(t)
+	fs := filesystem.NewFileSystem(newLocalFileSession(t))
+
+	_, err := fs.ReadFileBytes(filepath.Join(t.TempDir(), "missing"))
+
+	var fileErr *agentbay.FileError
+	require.True(t, errors.As(err, &fileErr))
+	assert.Equal(t, "read file", fileErr.Op)
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+// corruptingFileSession flips a byte of every chunk the shell tool returns
+type corruptingFileSession struct {
+	*localFileSession
+}
+
+func (s *corruptingFileSession) CallMcpTool(toolName string, args interface{}) (*models.McpToolResult, error) {
+	result, err := s.localFileSession.CallMcpTool(toolName, args)
+	if err == nil && toolName == "shell" {
+		if command := args.(map[string]interface{})["command"].(string); strings.HasSuffix(command, "| base64") {
+			result.Data = strings.Replace(result.Data, "A", "B", 1)
+		}
+	}
+	return result, err
+}
+
+func TestFileSystem_ReadFileBytesDetectsCorruption(t *testing.T) {
+	requireLocalFileTools(t)
+	fs := filesystem.NewFileSystem(&corruptingFileSession{newLocalFileSession(t)})
+	path := filepath.Join(t.TempDir(), "data.bin")
+	require.NoError(t, os.WriteFile(path, bytes.Repeat([]byte{0}, 64), 0o644))
+
+	_, err := fs.ReadFileBytes(path)
+
+	assert.True(t, errors.Is(err, filesystem.ErrChecksumMismatch))
+}
@@ -0,0 +1,120 @@
+package agentbay_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/filesystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func requireLocalSyncTools(t *testing.T) {
+	requireLocalFileTools(t)
+	for _, tool := range []string{"find", "tar", "touch"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s is not available", tool)
+		}
+	}
+}
+
+// writeTree creates the given files below dir
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(target), 0o755))
+		require.NoError(t, os.WriteFile(target, []byte(content), 0o644))
+	}
+}
+
+func readFileString(t *testing.T, name string) string {
+	data, err := os.ReadFile(name)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestFileSystem_UploadDirSkipsUnchangedFiles(t *testing.T) {
+	requireLocalSyncTools(t)
+	for _, archive := range []bool{false, true} {
+		t.Run(map[bool]string{false: "files", true: "archive"}[archive], func(t *testing.T) {
+			fs := filesystem.NewFileSystem(newLocalFileSession(t))
+			local, remote := t.TempDir(), filepath.Join(t.TempDir(), "project")
+			writeTree(t, local, map[string]string{
+				"main.go":               "package main\n",
+				"pkg/util/util.go":      "package util\n",
+				"it's here.txt":         "quoted",
+				"node_modules/x/i.js":   "ignored",
+				"pkg/util/util_test.go": "package util\n",
+			})
+			options := &filesystem.DirSyncOptions{
+				Exclude: []string{"node_modules", "*_test.go"},
+				Archive: archive,
+			}
+
+			result, err := fs.UploadDir(context.Background(), local, remote, options)
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"it's here.txt", "main.go", "pkg/util/util.go"}, result.Transferred)
+			assert.Empty(t, result.Skipped)
+			assert.Equal(t, int64(32), result.Bytes)
+			assert.Equal(t, "package util\n", readFileString(t, filepath.Join(remote, "pkg/util/util.go")))
+			assert.NoFileExists(t, filepath.Join(remote, "node_modules/x/i.js"))
+			assert.NoFileExists(t, filepath.Join(remote, "pkg/util/util_test.go"))
+
+			modTime := time.Now().Add(time.Hour)
+			writeTree(t, local, map[string]string{"main.go": "package main // changed\n"})
+			require.NoError(t, os.Chtimes(filepath.Join(local, "main.go"), modTime, modTime))
+
+			result, err = fs.UploadDir(context.Background(), local, remote, options)
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"main.go"}, result.Transferred)
+			assert.Equal(t, []string{"it's here.txt", "pkg/util/util.go"}, result.Skipped)
+			assert.Equal(t, "package main // changed\n", readFileString(t, filepath.Join(remote, "main.go")))
+		})
+	}
+}
+
+func TestFileSystem_DownloadDir(t *testing.T) {
+	requireLocalSyncTools(t)
+	for _, archive := range []bool{false, true} {
+		t.Run(map[bool]string{false: "files", true: "archive"}[archive], func(t *testing.T) {
+			fs := filesystem.NewFileSystem(newLocalFileSession(t))
+			remote, local := t.TempDir(), filepath.Join(t.TempDir(), "results")
+			writeTree(t, remote, map[string]string{
+				"out/report.json": `{"ok":true}`,
+				"out/run.log":     "log",
+				"summary.json":    "{}",
+			})
+			options := &filesystem.DirSyncOptions{Include: []string{"*.json"}, Compare: filesystem.CompareHash, Archive: archive}
+
+			result, err := fs.DownloadDir(context.Background(), remote, local, options)
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"out/report.json", "summary.json"}, result.Transferred)
+			assert.Equal(t, `{"ok":true}`, readFileString(t, filepath.Join(local, "out/report.json")))
+			assert.NoFileExists(t, filepath.Join(local, "out/run.log"))
+
+			writeTree(t, remote, map[string]string{"summary.json": `{"n":1}`})
+			result, err = fs.DownloadDir(context.Background(), remote, local, options)
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"summary.json"}, result.Transferred)
+			assert.Equal(t, []string{"out/report.json"}, result.Skipped)
+			assert.Equal(t, `{"n":1}`, readFileString(t, filepath.Join(local, "summary.json")))
+		})
+	}
+}
+
+func TestFileSystem_DownloadDirMissing(t *testing.T) {
+	requireLocalSyncTools(t)
+	fs := filesystem.NewFileSystem(newLocalFileSession(t))
+
+	_, err := fs.DownloadDir(context.Background(), filepath.Join(t.TempDir(), "missing"), t.TempDir(), nil)
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,162 @@
+package agentbay_test
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/filesystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// editFile creates a file with content in a new temporary directory
+func editFile(t *testing.T, content string) string {
+	name := filepath.Join(t.TempDir(), "main.go")
+	require.NoError(t, os.WriteFile(name, []byte(content), 0o644))
+	return name
+}
+
+func readString(t *testing.T, name string) string {
+	data, err := os.ReadFile(name)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	oldContent := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\nn"
+	newContent := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\nN\no\n"
+
+	assert.Equal(t, `--- f.txt
++++ f.txt
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -11,4 +11,5 @@
+ k
+ l
+ m
+-n
+\ No newline at end of file
++N
++o
+`, filesystem.UnifiedDiff("f.txt", oldContent, newContent))
+	assert.Empty(t, filesystem.UnifiedDiff("f.txt", oldContent, oldContent))
+	assert.Equal(t, "--- f.txt\n+++ f.txt\n@@ -0,0 +1 @@\n+x\n", filesystem.UnifiedDiff("f.txt", "", "x\n"))
+}
+
+func TestFileSystem_ApplyPatchRoundTrip(t *testing.T) {
+	requireLocalFileTools(t)
+	fs := filesystem.NewFileSystem(newLocalFileSession(t))
+	random := rand.New(rand.NewSource(1))
+	randomText := func() string {
+		lines := make([]string, random.Intn(40))
+		for i := range lines {
+			lines[i] = fmt.Sprintf("line %d", random.Intn(8))
+		}
+		text := strings.Join(lines, "\n")
+		if random.Intn(2) == 0 && text != "" {
+			text += "\n"
+		}
+		return text
+	}
+
+	for i := 0; i < 50; i++ {
+		oldContent, newContent := randomText(), randomText()
+		name := editFile(t, oldContent)
+		patch := filesystem.UnifiedDiff(name, oldContent, newContent)
+		if patch == "" {
+			continue
+		}
+
+		result, err := fs.ApplyPatch(t.Context(), name, patch, false)
+
+		require.NoError(t, err, patch)
+		assert.Equal(t, newContent, readString(t, name), patch)
+		assert.Equal(t, patch, result.Diff)
+	}
+}
+
+func TestFileSystem_ApplyPatchWithOffsetAndDryRun(t *testing.T) {
+	requireLocalFileTools(t)
+	fs := filesystem.NewFileSystem(newLocalFileSession(t))
+	original := "package main\n\n// added since the patch was made\n\nfunc main() {\n\tprintln(\"hello\")\n}\n"
+	name := editFile(t, original)
+	patch := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -3,3 +3,4 @@
+ func main() {
+-	println("hello")
++	println("hello, world")
++	println("bye")
+ }
+`
+
+	result, err := fs.ApplyPatch(t.Context(), name, patch, true)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.True(t, result.DryRun)
+	require.Len(t, result.Hunks, 1)
+	assert.Equal(t, "@@ -3,3 +3,4 @@", result.Hunks[0].Header)
+	assert.True(t, result.Hunks[0].Applied)
+	assert.Equal(t, 2, result.Hunks[0].Offset)
+	assert.Contains(t, result.Diff, "+\tprintln(\"bye\")\n")
+	assert.Equal(t, original, readString(t, name))
+
+	_, err = fs.ApplyPatch(t.Context(), name, patch, false)
+	require.NoError(t, err)
+	assert.Contains(t, readString(t, name), "hello, world")
+}
+
+func TestFileSystem_ApplyPatchFailedHunk(t *testing.T) {
+	requireLocalFileTools(t)
+	fs := filesystem.NewFileSystem(newLocalFileSession(t))
+	original := "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\n"
+	name := editFile(t, original)
+	patch := "@@ -1,2 +1,2 @@\n one\n-two\n+TWO\n@@ -8,2 +8,2 @@\n-eight\n+EIGHT\n ten\n"
+
+	result, err := fs.ApplyPatch(t.Context(), name, patch, false)
+
+	assert.ErrorContains(t, err, "1 of 2 hunks failed to apply")
+	require.NotNil(t, result)
+	assert.False(t, result.Success)
+	require.Len(t, result.Hunks, 2)
+	assert.True(t, result.Hunks[0].Applied)
+	assert.False(t, result.Hunks[1].Applied)
+	assert.Equal(t, "hunk context does not match the file", result.Hunks[1].Error)
+	assert.Equal(t, original, readString(t, name))
+
+	_, err = fs.ApplyPatch(t.Context(), name, "not a patch", false)
+	assert.ErrorContains(t, err, "patch contains no hunks")
+}
+
+func TestFileSystem_ApplyEdits(t *testing.T) {
+	requireLocalFileTools(t)
+	fs := filesystem.NewFileSystem(newLocalFileSession(t))
+	name := editFile(t, "Hello, world!\nkeep\nGoodbye\n")
+
+	result, err := fs.ApplyEdits(t.Context(), name, []filesystem.Edit{
+		{Old: "Hello", New: "Hi"},
+		{Old: "Goodbye\n", New: "Goodbye\nagain\n"},
+	}, false)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "Hi, world!\nkeep\nGoodbye\nagain\n", readString(t, name))
+	assert.Equal(t, "--- "+name+"\n+++ "+name+"\n@@ -1,3 +1,4 @@\n-Hello, world!\n+Hi, world!\n keep\n Goodbye\n+again\n", result.Diff)
+	require.Len(t, result.Hunks, 1)
+	assert.True(t, result.Hunks[0].Applied)
+
+	_, err = fs.ApplyEdits(t.Context(), name, []filesystem.Edit{{Old: "Hi", New: "Hey"}, {Old: "missing", New: "x"}}, false)
+	assert.ErrorContains(t, err, "edit 1: old text not found")
+	assert.Equal(t, "Hi, world!\nkeep\nGoodbye\nagain\n", readString(t, name))
+}
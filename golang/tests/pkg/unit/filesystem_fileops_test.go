@@ -0,0 +1,114 @@
+package agentbay_test
+
+import (
+	"context"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/filesystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSystem_CopyChmodAndRemove(t *testing.T) {
+	requireLocalSyncTools(t)
+	fs := filesystem.NewFileSystem(&localShellSession{t: t})
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{"it's a dir/a.txt": "a", "it's a dir/sub/b.txt": "b"})
+	src, dst := filepath.Join(root, "it's a dir"), filepath.Join(root, "copy $(touch pwned)")
+
+	_, err := fs.Copy(src, dst, false)
+	assert.Error(t, err)
+
+	result, err := fs.Copy(src, dst, true)
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "b", readFileString(t, filepath.Join(dst, "sub/b.txt")))
+	assert.NoFileExists(t, filepath.Join(root, "pwned"))
+
+	_, err = fs.Chmod(filepath.Join(dst, "a.txt"), 0o600|os.ModeSetgid)
+	require.NoError(t, err)
+	info, err := os.Stat(filepath.Join(dst, "a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, 0o600|os.ModeSetgid, info.Mode()&(os.ModePerm|os.ModeSetgid))
+
+	_, err = fs.Remove(dst, false)
+	assert.Error(t, err)
+	_, err = fs.Remove(dst, true)
+	require.NoError(t, err)
+	assert.NoDirExists(t, dst)
+	_, err = fs.Remove(dst, true)
+	assert.NoError(t, err)
+
+	_, err = fs.Remove(dst, false)
+	assert.ErrorIs(t, err, iofs.ErrNotExist)
+	_, err = fs.Remove("/", true)
+	assert.Error(t, err)
+}
+
+func TestFileSystem_SymlinkAndStat(t *testing.T) {
+	requireLocalSyncTools(t)
+	fs := filesystem.NewFileSystem(&localShellSession{t: t})
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{"target.txt": "target"})
+	link := filepath.Join(root, "link")
+
+	_, err := fs.Symlink("target.txt", link)
+	require.NoError(t, err)
+	_, err = fs.Symlink("target.txt", link)
+	assert.ErrorIs(t, err, iofs.ErrExist)
+
+	// An existing directory is not used as the parent of the link
+	dir := filepath.Join(root, "dir")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	_, err = fs.Symlink("target.txt", dir)
+	assert.ErrorIs(t, err, iofs.ErrExist)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	result, err := fs.Stat(link)
+	require.NoError(t, err)
+	assert.True(t, result.FileInfo.IsSymlink)
+	assert.Equal(t, "link", result.FileInfo.Name)
+	assert.Equal(t, iofs.ModeSymlink, result.FileInfo.FileMode().Type())
+
+	result, err = fs.Stat(filepath.Join(root, "target.txt"))
+	require.NoError(t, err)
+	assert.False(t, result.FileInfo.IsSymlink)
+	assert.Equal(t, int64(6), result.FileInfo.Size)
+
+	_, err = fs.Stat(filepath.Join(root, "missing"))
+	assert.ErrorIs(t, err, iofs.ErrNotExist)
+	_, err = fs.Stat("")
+	assert.Error(t, err)
+}
+
+func TestFileSystem_FileOpsWithContext(t *testing.T) {
+	requireLocalSyncTools(t)
+	fs := filesystem.NewFileSystem(&localShellSession{t: t})
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{"a.txt": "a"})
+	src, dst, link := filepath.Join(root, "a.txt"), filepath.Join(root, "b.txt"), filepath.Join(root, "link")
+
+	_, err := fs.CopyWithContext(t.Context(), src, dst, false)
+	require.NoError(t, err)
+	_, err = fs.ChmodWithContext(t.Context(), dst, 0o640)
+	require.NoError(t, err)
+	_, err = fs.SymlinkWithContext(t.Context(), dst, link)
+	require.NoError(t, err)
+	info, err := os.Stat(link)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+	_, err = fs.RemoveWithContext(t.Context(), link, false)
+	require.NoError(t, err)
+	assert.NoFileExists(t, link)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err = fs.RemoveWithContext(ctx, dst, false)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.FileExists(t, dst)
+}
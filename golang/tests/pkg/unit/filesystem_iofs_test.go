@@ -0,0 +1,133 @@
+package agentbay_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/filesystem"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// directoryFileSession adds list_directory and detailed get_file_info results to
+// localFileSession
+type directoryFileSession struct {
+	*localFileSession
+}
+
+func (s *directoryFileSession) CallMcpTool(toolName string, args interface{}) (*models.McpToolResult, error) {
+	encoded, err := json.Marshal(args)
+	require.NoError(s.t, err)
+	var argMap map[string]interface{}
+	require.NoError(s.t, json.Unmarshal(encoded, &argMap))
+
+	switch toolName {
+	case "list_directory":
+		s.record(toolName)
+		entries, err := os.ReadDir(argMap["path"].(string))
+		if err != nil {
+			return &models.McpToolResult{Success: false, ErrorMessage: err.Error(), RequestID: "req-list"}, nil
+		}
+		var lines []string
+		for _, entry := range entries {
+			kind := "[FILE]"
+			if entry.IsDir() {
+				kind = "[DIR]"
+			}
+			lines = append(lines, kind+" "+entry.Name())
+		}
+		// The tool does not sort its output
+		sort.Sort(sort.Reverse(sort.StringSlice(lines)))
+		return &models.McpToolResult{Success: true, Data: strings.Join(lines, "\n"), RequestID: "req-list"}, nil
+	case "get_file_info":
+		s.record(toolName)
+		info, err := os.Stat(argMap["path"].(string))
+		if err != nil {
+			return &models.McpToolResult{Success: false, ErrorMessage: err.Error(), RequestID: "req-info"}, nil
+		}
+		return &models.McpToolResult{
+			Success: true,
+			Data: fmt.Sprintf("size: %d\nisDirectory: %t\npermissions: %s\nmodified: %s", info.Size(), info.IsDir(),
+				info.Mode().Perm(), info.ModTime().UTC().Format(time.RFC3339Nano)),
+			RequestID: "req-info",
+		}, nil
+	}
+	return s.localFileSession.CallMcpTool(toolName, args)
+}
+
+func (s *directoryFileSession) record(toolName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tools = append(s.tools, toolName)
+}
+
+func TestFileSystem_FSPassesTestFS(t *testing.T) {
+	session := &directoryFileSession{newLocalFileSession(t)}
+	fs := filesystem.NewFileSystem(session)
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"index.html":          "<h1>{{.Title}}</h1>",
+		"static/app.js":       "console.log('ok')",
+		"static/css/site.css": "body {}",
+		"templates/a.tmpl":    "a",
+		"templates/b.tmpl":    "b ✓",
+	})
+
+	fsys := fs.FS(root)
+
+	require.NoError(t, fstest.TestFS(fsys, "index.html", "static/app.js", "static/css/site.css", "templates/b.tmpl"))
+	matches, err := iofs.Glob(fsys, "templates/*.tmpl")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"templates/a.tmpl", "templates/b.tmpl"}, matches)
+	data, err := iofs.ReadFile(fsys, "templates/b.tmpl")
+	require.NoError(t, err)
+	assert.Equal(t, "b ✓", string(data))
+}
+
+func TestFileSystem_FSErrors(t *testing.T) {
+	fs := filesystem.NewFileSystem(&directoryFileSession{newLocalFileSession(t)})
+	fsys := fs.FS(t.TempDir())
+
+	_, err := fsys.Open("missing.txt")
+	var pathErr *iofs.PathError
+	require.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, "missing.txt", pathErr.Path)
+	assert.ErrorIs(t, err, iofs.ErrNotExist)
+
+	_, err = fsys.Open("../etc/passwd")
+	assert.ErrorIs(t, err, iofs.ErrInvalid)
+}
+
+func TestFileSystem_FSCache(t *testing.T) {
+	session := &directoryFileSession{newLocalFileSession(t)}
+	fs := filesystem.NewFileSystem(session)
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{"a.txt": "first"})
+	fsys := fs.FSWithOptions(t.Context(), root, &filesystem.FSOptions{CacheTTL: time.Minute})
+
+	for i := 0; i < 3; i++ {
+		data, err := fsys.ReadFile("a.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "first", string(data))
+		_, err = fsys.ReadDir(".")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, countTools(session.tools, "read_file"))
+	assert.Equal(t, 1, countTools(session.tools, "list_directory"))
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "a.txt"), []byte("second"), 0o644))
+	fsys.Invalidate()
+	data, err := fsys.ReadFile("a.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+}
@@ -0,0 +1,116 @@
+package agentbay_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/filesystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSystem_OpenStreamsChunks(t *testing.T) {
+	session := newLocalFileSession(t)
+	fs := filesystem.NewFileSystem(session)
+	path := filepath.Join(t.TempDir(), "data.txt")
+	content := strings.Repeat("0123456789", 25)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	var progress []int64
+	reader, err := fs.OpenWithContext(context.Background(), path, &filesystem.StreamOptions{
+		ChunkSize: 100,
+		Progress: func(transferred, total int64) {
+			assert.Equal(t, int64(250), total)
+			progress = append(progress, transferred)
+		},
+	})
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+	assert.Equal(t, []int64{100, 200, 250}, progress)
+	assert.Equal(t, []string{"get_file_info", "read_file", "read_file", "read_file"}, session.tools)
+
+	require.NoError(t, reader.Close())
+	_, err = reader.Read(make([]byte, 1))
+	assert.True(t, errors.Is(err, filesystem.ErrClosed))
+}
+
+func TestFileSystem_OpenEmptyAndMissingFile(t *testing.T) {
+	fs := filesystem.NewFileSystem(newLocalFileSession(t))
+	dir := t.TempDir()
+	path := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(path, nil, 0o644))
+
+	reader, err := fs.Open(path)
+	require.NoError(t, err)
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	_, err = fs.Open(filepath.Join(dir, "missing"))
+	var fileErr *agentbay.FileError
+	assert.True(t, errors.As(err, &fileErr))
+
+	_, err = fs.Open(dir)
+	assert.True(t, errors.As(err, &fileErr))
+}
+
+func TestFileSystem_CreateStreamsChunksAtCharacterBoundaries(t *testing.T) {
+	session := newLocalFileSession(t)
+	fs := filesystem.NewFileSystem(session)
+	path := filepath.Join(t.TempDir(), "out.txt")
+	require.NoError(t, os.WriteFile(path, []byte("previous contents"), 0o644))
+
+	var progress []int64
+	writer, err := fs.CreateWithContext(context.Background(), path, &filesystem.StreamOptions{
+		ChunkSize: 10,
+		Progress: func(transferred, total int64) {
+			assert.Equal(t, int64(-1), total)
+			progress = append(progress, transferred)
+		},
+	})
+	require.NoError(t, err)
+
+	// Multi-byte characters straddle the chunk boundaries and are written byte by byte
+	content := strings.Repeat("héllo wörld ✓ ", 5)
+	for i := 0; i < len(content); i++ {
+		n, err := writer.Write([]byte{content[i]})
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+	}
+	require.NoError(t, writer.Close())
+	require.NoError(t, writer.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+	require.NotEmpty(t, progress)
+	assert.Equal(t, int64(len(content)), progress[len(progress)-1])
+
+	_, err = writer.Write([]byte("more"))
+	assert.True(t, errors.Is(err, filesystem.ErrClosed))
+}
+
+func TestFileSystem_CreateWithoutWritesTruncates(t *testing.T) {
+	session := newLocalFileSession(t)
+	fs := filesystem.NewFileSystem(session)
+	path := filepath.Join(t.TempDir(), "out.txt")
+	require.NoError(t, os.WriteFile(path, []byte("previous contents"), 0o644))
+
+	writer, err := fs.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+	assert.Equal(t, []string{"write_file"}, session.tools)
+}
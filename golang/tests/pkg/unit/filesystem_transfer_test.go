@@ -0,0 +1,187 @@
+package agentbay_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/filesystem"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingReporter records the progress reported by a transfer
+type recordingReporter struct {
+	transferred []int64
+	total       int64
+}
+
+func (r *recordingReporter) Report(transferred, total int64) {
+	r.transferred = append(r.transferred, transferred)
+	r.total = total
+}
+
+// flakyFileSession fails the first call of a tool for which fail returns true
+type flakyFileSession struct {
+	*localFileSession
+	fail   func(toolName string, args map[string]interface{}) bool
+	mu     sync.Mutex
+	failed bool
+}
+
+func (s *flakyFileSession) CallMcpTool(toolName string, args interface{}) (*models.McpToolResult, error) {
+	if argMap, ok := args.(map[string]interface{}); ok {
+		s.mu.Lock()
+		fail := !s.failed && s.fail(toolName, argMap)
+		s.failed = s.failed || fail
+		s.mu.Unlock()
+		if fail {
+			return &models.McpToolResult{Success: false, ErrorMessage: "connection reset", RequestID: "req-flaky"}, nil
+		}
+	}
+	return s.localFileSession.CallMcpTool(toolName, args)
+}
+
+func countTools(tools []string, name string) int {
+	count := 0
+	for _, tool := range tools {
+		if tool == name {
+			count++
+		}
+	}
+	return count
+}
+
+func TestFileSystem_ReadFileParallel(t *testing.T) {
+	session := newLocalFileSession(t)
+	fs := filesystem.NewFileSystem(session)
+	path := filepath.Join(t.TempDir(), "data.txt")
+	content := strings.Repeat("abcdefghij", 100)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	reporter := &recordingReporter{}
+	result, err := fs.ReadFileParallel(context.Background(), path, &filesystem.TransferOptions{
+		ChunkSize:   64,
+		Concurrency: 3,
+		Progress:    reporter,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, content, result.Content)
+	assert.Equal(t, 16, countTools(session.tools, "read_file"))
+	assert.Len(t, reporter.transferred, 16)
+	assert.Equal(t, int64(1000), reporter.transferred[15])
+	assert.Equal(t, int64(1000), reporter.total)
+	assert.IsIncreasing(t, reporter.transferred)
+}
+
+func TestFileSystem_ReadFileParallelResume(t *testing.T) {
+	session := &flakyFileSession{
+		localFileSession: newLocalFileSession(t),
+		fail: func(toolName string, args map[string]interface{}) bool {
+			return toolName == "read_file" && args["offset"] == 300
+		},
+	}
+	fs := filesystem.NewFileSystem(session)
+	path := filepath.Join(t.TempDir(), "data.txt")
+	content := strings.Repeat("0123456789", 50)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	_, err := fs.ReadFileParallel(context.Background(), path, &filesystem.TransferOptions{ChunkSize: 100, Concurrency: 1})
+
+	var transferErr *filesystem.TransferError
+	require.True(t, errors.As(err, &transferErr))
+	assert.Equal(t, int64(300), transferErr.Checkpoint.Offset())
+	assert.Equal(t, []bool{true, true, true, false, false}, transferErr.Checkpoint.Completed)
+
+	reads := countTools(session.tools, "read_file")
+	result, err := fs.ReadFileParallel(context.Background(), path, &filesystem.TransferOptions{Resume: transferErr.Checkpoint})
+	require.NoError(t, err)
+	assert.Equal(t, content, result.Content)
+	assert.Equal(t, 2, countTools(session.tools, "read_file")-reads)
+}
+
+func TestFileSystem_WriteFileParallel(t *testing.T) {
+	session := newLocalFileSession(t)
+	fs := filesystem.NewFileSystem(session)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.txt")
+	content := strings.Repeat("zürich ✓ ", 40)
+
+	reporter := &recordingReporter{}
+	result, err := fs.WriteFileParallel(context.Background(), path, content, &filesystem.TransferOptions{
+		ChunkSize:   50,
+		Concurrency: 4,
+		Progress:    reporter,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+	assert.Equal(t, int64(len(content)), reporter.transferred[len(reporter.transferred)-1])
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestFileSystem_WriteFileParallelTinyChunks(t *testing.T) {
+	session := newLocalFileSession(t)
+	fs := filesystem.NewFileSystem(session)
+	path := filepath.Join(t.TempDir(), "out.txt")
+	content := "a€✓😀b"
+
+	result, err := fs.WriteFileParallel(context.Background(), path, content, &filesystem.TransferOptions{ChunkSize: 1})
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+}
+
+func TestFileSystem_WriteFileParallelResume(t *testing.T) {
+	session := &flakyFileSession{
+		localFileSession: newLocalFileSession(t),
+		fail: func(toolName string, args map[string]interface{}) bool {
+			path, _ := args["path"].(string)
+			return toolName == "write_file" && strings.HasSuffix(path, ".parts/2")
+		},
+	}
+	fs := filesystem.NewFileSystem(session)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.txt")
+	require.NoError(t, os.WriteFile(path, []byte("previous contents"), 0o644))
+	content := strings.Repeat("0123456789", 50)
+	options := &filesystem.TransferOptions{ChunkSize: 100, Concurrency: 1}
+
+	_, err := fs.WriteFileParallel(context.Background(), path, content, options)
+
+	var transferErr *filesystem.TransferError
+	require.True(t, errors.As(err, &transferErr))
+	assert.Equal(t, int64(200), transferErr.Checkpoint.Offset())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "previous contents", string(data))
+
+	writes := countTools(session.tools, "write_file")
+	options.Resume = transferErr.Checkpoint
+	_, err = fs.WriteFileParallel(context.Background(), path, content, options)
+	require.NoError(t, err)
+	assert.Equal(t, 3, countTools(session.tools, "write_file")-writes)
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	_, err = fs.WriteFileParallel(context.Background(), path, content+"x", options)
+	assert.Error(t, err)
+}
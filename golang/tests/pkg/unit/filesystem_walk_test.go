@@ -0,0 +1,136 @@
+package agentbay_test
+
+import (
+	"errors"
+	iofs "io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/filesystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// walkTree creates a small tree with a symbolic link below a new temporary directory
+func walkTree(t *testing.T) string {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"b.txt":          "bbb",
+		"a/z.go":         "package z",
+		"a/y.go":         "package y",
+		"a/skip/x.go":    "package x",
+		"c/d/e.md":       "# e",
+		"c/d/f.md":       "# f",
+		"c/g with space": "g",
+	})
+	require.NoError(t, os.Symlink("b.txt", filepath.Join(root, "link")))
+	modTime := time.Date(2024, 5, 1, 12, 0, 0, 500, time.UTC)
+	require.NoError(t, os.Chtimes(filepath.Join(root, "b.txt"), modTime, modTime))
+	require.NoError(t, os.Chmod(filepath.Join(root, "b.txt"), 0o640))
+	return root
+}
+
+func TestFileSystem_WalkMatchesWalkDir(t *testing.T) {
+	requireLocalSyncTools(t)
+	fs := filesystem.NewFileSystem(&localShellSession{t: t})
+	root := walkTree(t)
+
+	var expected []string
+	require.NoError(t, filepath.WalkDir(root, func(name string, d iofs.DirEntry, err error) error {
+		if d.Name() == "skip" {
+			return filepath.SkipDir
+		}
+		expected = append(expected, name)
+		return err
+	}))
+
+	var walked []string
+	infos := map[string]*filesystem.FileInfo{}
+	err := fs.Walk(root, func(path string, info *filesystem.FileInfo, err error) error {
+		if info.Name == "skip" {
+			return iofs.SkipDir
+		}
+		walked = append(walked, path)
+		infos[info.Name] = info
+		return err
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, walked)
+	assert.True(t, infos["a"].IsDirectory)
+	assert.True(t, infos["link"].IsSymlink)
+	assert.Equal(t, iofs.ModeSymlink, infos["link"].FileMode().Type())
+	assert.Equal(t, int64(3), infos["b.txt"].Size)
+	assert.Equal(t, iofs.FileMode(0o640), infos["b.txt"].FileMode())
+	assert.Equal(t, "-rw-r-----", infos["b.txt"].Mode)
+	assert.True(t, infos["b.txt"].ModTimeValue().Equal(time.Date(2024, 5, 1, 12, 0, 0, 500, time.UTC)))
+}
+
+func TestFileSystem_WalkSkipsRemainingFilesAndStops(t *testing.T) {
+	requireLocalSyncTools(t)
+	fs := filesystem.NewFileSystem(&localShellSession{t: t})
+	root := walkTree(t)
+
+	var walked []string
+	err := fs.Walk(root, func(path string, info *filesystem.FileInfo, err error) error {
+		rel, _ := filepath.Rel(root, path)
+		walked = append(walked, filepath.ToSlash(rel))
+		switch rel {
+		case "a/skip/x.go", "a/y.go":
+			return iofs.SkipDir
+		case "c/d/e.md":
+			return iofs.SkipAll
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{".", "a", "a/skip", "a/skip/x.go", "a/y.go", "b.txt", "c", "c/d", "c/d/e.md"}, walked)
+
+	stop := errors.New("stop")
+	err = fs.Walk(root, func(path string, info *filesystem.FileInfo, err error) error { return stop })
+	assert.Equal(t, stop, err)
+}
+
+func TestFileSystem_WalkMissingRoot(t *testing.T) {
+	requireLocalSyncTools(t)
+	fs := filesystem.NewFileSystem(&localShellSession{t: t})
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	var calls int
+	err := fs.Walk(missing, func(path string, info *filesystem.FileInfo, err error) error {
+		calls++
+		assert.Equal(t, missing, path)
+		assert.Nil(t, info)
+		return err
+	})
+
+	assert.Equal(t, 1, calls)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFileSystem_Glob(t *testing.T) {
+	requireLocalSyncTools(t)
+	fs := filesystem.NewFileSystem(&localShellSession{t: t})
+	root := walkTree(t)
+
+	for _, pattern := range []string{"*/*.go", "c/*/?.md", "*", "c/g with space", "nothing/*"} {
+		expected, err := filepath.Glob(filepath.Join(root, pattern))
+		require.NoError(t, err)
+
+		result, err := fs.Glob(filepath.Join(root, pattern))
+
+		require.NoError(t, err)
+		var matched []string
+		for _, info := range result.Matches {
+			matched = append(matched, info.Path)
+		}
+		assert.Equal(t, expected, matched, pattern)
+	}
+
+	_, err := fs.Glob(root + "/[")
+	assert.ErrorIs(t, err, path.ErrBadPattern)
+}
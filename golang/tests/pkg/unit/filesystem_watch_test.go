@@ -1,6 +1,8 @@
 package agentbay_test
 
 import (
+	"context"
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -10,6 +12,7 @@ import (
 	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockWatchSession for testing watch directory functionality
@@ -306,3 +309,132 @@ func TestFileSystem_WatchDirectory_Basic(t *testing.T) {
 
 	mockSession.AssertExpectations(t)
 }
+
+// scriptedWatchSession returns the queued get_file_change results in order and an empty change
+// list once the queue is exhausted
+type scriptedWatchSession struct {
+	localShellSession
+	mu      sync.Mutex
+	results []*models.McpToolResult
+	polls   []time.Time
+}
+
+func (s *scriptedWatchSession) CallMcpTool(toolName string, args interface{}) (*models.McpToolResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.polls = append(s.polls, time.Now())
+	if len(s.results) == 0 {
+		return &models.McpToolResult{Success: true, Data: "[]", RequestID: "req-change"}, nil
+	}
+	result := s.results[0]
+	s.results = s.results[1:]
+	return result, nil
+}
+
+func changeResult(events string) *models.McpToolResult {
+	return &models.McpToolResult{Success: true, Data: events, RequestID: "req-change"}
+}
+
+func collectEvents(t *testing.T, watcher *filesystem.Watcher, n int) []filesystem.FileChangeEvent {
+	var events []filesystem.FileChangeEvent
+	timeout := time.After(2 * time.Second)
+	for len(events) < n {
+		select {
+		case event := <-watcher.Events():
+			events = append(events, event)
+		case <-timeout:
+			t.Fatalf("received %d of %d events", len(events), n)
+		}
+	}
+	return events
+}
+
+func TestFileSystem_WatchFiltersEvents(t *testing.T) {
+	session := &scriptedWatchSession{results: []*models.McpToolResult{
+		changeResult(`[{"eventType":"create","path":"/tmp/w/a.txt","pathType":"file"},
+			{"eventType":"create","path":"/tmp/w/sub/b.txt","pathType":"file"},
+			{"eventType":"modify","path":"/tmp/w/c.log","pathType":"file"},
+			{"eventType":"modify","path":"/tmp/w/.git/index","pathType":"file"}]`),
+	}}
+	fs := filesystem.NewFileSystem(session)
+
+	watcher, err := fs.Watch(context.Background(), "/tmp/w", &filesystem.WatchOptions{
+		Interval:  10 * time.Millisecond,
+		Recursive: true,
+		Exclude:   []string{".git", "*.log"},
+	})
+	assert.NoError(t, err)
+	events := collectEvents(t, watcher, 2)
+	assert.NoError(t, watcher.Close())
+	assert.NoError(t, watcher.Close())
+
+	assert.Equal(t, "/tmp/w/a.txt", events[0].Path)
+	assert.Equal(t, "/tmp/w/sub/b.txt", events[1].Path)
+	_, open := <-watcher.Events()
+	assert.False(t, open)
+}
+
+func TestFileSystem_WatchDebouncesBursts(t *testing.T) {
+	session := &scriptedWatchSession{results: []*models.McpToolResult{
+		changeResult(`[{"eventType":"create","path":"/tmp/w/a.txt","pathType":"file"},
+			{"eventType":"create","path":"/tmp/w/tmp.swp","pathType":"file"}]`),
+		changeResult(`[{"eventType":"modify","path":"/tmp/w/a.txt","pathType":"file"},
+			{"eventType":"delete","path":"/tmp/w/tmp.swp","pathType":"file"},
+			{"eventType":"modify","path":"/tmp/w/b.txt","pathType":"file"}]`),
+		changeResult(`[{"eventType":"delete","path":"/tmp/w/b.txt","pathType":"file"}]`),
+	}}
+	fs := filesystem.NewFileSystem(session)
+
+	watcher, err := fs.Watch(context.Background(), "/tmp/w", &filesystem.WatchOptions{
+		Interval: 10 * time.Millisecond,
+		Debounce: 100 * time.Millisecond,
+		MaxDelay: time.Second,
+	})
+	assert.NoError(t, err)
+	defer watcher.Close()
+	events := collectEvents(t, watcher, 2)
+
+	assert.Equal(t, []filesystem.FileChangeEvent{
+		{EventType: "create", Path: "/tmp/w/a.txt", PathType: "file"},
+		{EventType: "delete", Path: "/tmp/w/b.txt", PathType: "file"},
+	}, events)
+	select {
+	case event := <-watcher.Events():
+		t.Fatalf("unexpected event %v", event)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestFileSystem_WatchBacksOffOnErrors(t *testing.T) {
+	failure := &models.McpToolResult{Success: false, ErrorMessage: "service unavailable", RequestID: "req-change"}
+	session := &scriptedWatchSession{results: []*models.McpToolResult{failure, failure, failure}}
+	fs := filesystem.NewFileSystem(session)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	watcher, err := fs.Watch(ctx, "/tmp/w", &filesystem.WatchOptions{
+		Interval:   10 * time.Millisecond,
+		MaxBackoff: 40 * time.Millisecond,
+	})
+	assert.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		select {
+		case err := <-watcher.Errors():
+			var toolErr *models.ToolError
+			assert.True(t, errors.As(err, &toolErr))
+		case <-time.After(time.Second):
+			t.Fatal("no error reported")
+		}
+	}
+	require.Eventually(t, func() bool {
+		session.mu.Lock()
+		defer session.mu.Unlock()
+		return len(session.polls) >= 4
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	<-watcher.Done()
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	assert.GreaterOrEqual(t, session.polls[2].Sub(session.polls[1]), 35*time.Millisecond)
+	assert.GreaterOrEqual(t, session.polls[3].Sub(session.polls[2]), 35*time.Millisecond)
+}
@@ -0,0 +1,86 @@
+package agentbay_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay"
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBufferLogger returns a debug-level slog-backed Logger writing JSON records to buf
+func newBufferLogger(buf *bytes.Buffer) logger.Logger {
+	handler := slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	return logger.NewSlogLogger(slog.New(handler))
+}
+
+func TestSlogLogger_RedactsAPIKeys(t *testing.T) {
+	var buf bytes.Buffer
+	l := newBufferLogger(&buf)
+
+	l.Error("request with Bearer akm-0123abcd failed",
+		logger.KeyError, errors.New("GET /callTool?apiKey=akm-deadbeef-0001 refused"),
+		"authorization", "Bearer akm-feedface",
+		slog.String("token", "akm-cafe-babe"),
+	)
+
+	output := buf.String()
+	assert.NotEmpty(t, output)
+	assert.NotContains(t, output, "akm-")
+	assert.Contains(t, output, `"level":"ERROR"`)
+}
+
+func TestNopLogger_DiscardsOutput(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer slog.SetDefault(previous)
+
+	ab := newTestAgentBay(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"RequestId":"req-1","Data":{"Labels":"{}"}}`))
+	})
+	ab.SetLogger(logger.NewNopLogger())
+	session := agentbay.NewSession(ab, "session-123")
+
+	_, err := session.GetLabels()
+	require.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
+
+func TestSession_LogsStructuredAPIFields(t *testing.T) {
+	var buf bytes.Buffer
+	ab := newTestAgentBay(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"RequestId":"req-42","Data":{"Labels":"{\"env\":\"test\"}"}}`))
+	})
+	ab.SetLogger(newBufferLogger(&buf))
+	session := agentbay.NewSession(ab, "session-123")
+
+	_, err := session.GetLabels()
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, `"api":"GetLabel"`)
+	assert.Contains(t, output, `"session_id":"session-123"`)
+	assert.Contains(t, output, `"request_id":"req-42"`)
+	assert.Contains(t, output, `"duration":`)
+	assert.Contains(t, output, `"level":"DEBUG"`)
+}
+
+func TestNewAgentBay_WithLogger(t *testing.T) {
+	l := logger.NewNopLogger()
+	ab, err := agentbay.NewAgentBay("test-api-key",
+		agentbay.WithConfig(&agentbay.Config{Endpoint: "localhost:1", TimeoutMs: 1000}),
+		agentbay.WithLogger(l),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, l, ab.GetLogger())
+	assert.Equal(t, l, agentbay.NewSession(ab, "session-123").GetLogger())
+}
@@ -5,6 +5,7 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 
 	command "github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay/command"
@@ -53,3 +54,48 @@ func (mr *MockCommandInterfaceMockRecorder) ExecuteCommand(arg0 interface{}, arg
 	varargs := append([]interface{}{arg0}, arg1...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteCommand", reflect.TypeOf((*MockCommandInterface)(nil).ExecuteCommand), varargs...)
 }
+
+// Run mocks base method.
+func (m *MockCommandInterface) Run(arg0 context.Context, arg1 command.CommandOptions) (*command.CommandResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", arg0, arg1)
+	ret0, _ := ret[0].(*command.CommandResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockCommandInterfaceMockRecorder) Run(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockCommandInterface)(nil).Run), arg0, arg1)
+}
+
+// Start mocks base method.
+func (m *MockCommandInterface) Start(arg0 context.Context, arg1 command.CommandOptions) (*command.Process, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", arg0, arg1)
+	ret0, _ := ret[0].(*command.Process)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockCommandInterfaceMockRecorder) Start(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockCommandInterface)(nil).Start), arg0, arg1)
+}
+
+// Stream mocks base method.
+func (m *MockCommandInterface) Stream(arg0 context.Context, arg1 command.CommandOptions) (*command.CommandStream, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", arg0, arg1)
+	ret0, _ := ret[0].(*command.CommandStream)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockCommandInterfaceMockRecorder) Stream(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockCommandInterface)(nil).Stream), arg0, arg1)
+}
@@ -5,6 +5,8 @@
 package mock
 
 import (
+	io "io"
+	fs "io/fs"
 	reflect "reflect"
 	sync "sync"
 	time "time"
@@ -36,6 +38,51 @@ func (m *MockFileSystemInterface) EXPECT() *MockFileSystemInterfaceMockRecorder
 	return m.recorder
 }
 
+// Chmod mocks base method.
+func (m *MockFileSystemInterface) Chmod(arg0 string, arg1 fs.FileMode) (*filesystem.FileWriteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Chmod", arg0, arg1)
+	ret0, _ := ret[0].(*filesystem.FileWriteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Chmod indicates an expected call of Chmod.
+func (mr *MockFileSystemInterfaceMockRecorder) Chmod(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Chmod", reflect.TypeOf((*MockFileSystemInterface)(nil).Chmod), arg0, arg1)
+}
+
+// Copy mocks base method.
+func (m *MockFileSystemInterface) Copy(arg0, arg1 string, arg2 bool) (*filesystem.FileWriteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Copy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*filesystem.FileWriteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Copy indicates an expected call of Copy.
+func (mr *MockFileSystemInterfaceMockRecorder) Copy(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockFileSystemInterface)(nil).Copy), arg0, arg1, arg2)
+}
+
+// Create mocks base method.
+func (m *MockFileSystemInterface) Create(arg0 string) (io.WriteCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(io.WriteCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFileSystemInterfaceMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFileSystemInterface)(nil).Create), arg0)
+}
+
 // CreateDirectory mocks base method.
 func (m *MockFileSystemInterface) CreateDirectory(arg0 string) (*filesystem.FileDirectoryResult, error) {
 	m.ctrl.T.Helper()
@@ -126,6 +173,36 @@ func (mr *MockFileSystemInterfaceMockRecorder) MoveFile(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveFile", reflect.TypeOf((*MockFileSystemInterface)(nil).MoveFile), arg0, arg1)
 }
 
+// Open mocks base method.
+func (m *MockFileSystemInterface) Open(arg0 string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", arg0)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockFileSystemInterfaceMockRecorder) Open(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockFileSystemInterface)(nil).Open), arg0)
+}
+
+// Pack mocks base method.
+func (m *MockFileSystemInterface) Pack(arg0 []string, arg1 string, arg2 filesystem.ArchiveFormat) (*filesystem.FileWriteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pack", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*filesystem.FileWriteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pack indicates an expected call of Pack.
+func (mr *MockFileSystemInterfaceMockRecorder) Pack(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pack", reflect.TypeOf((*MockFileSystemInterface)(nil).Pack), arg0, arg1, arg2)
+}
+
 // ReadFile mocks base method.
 func (m *MockFileSystemInterface) ReadFile(arg0 string) (*filesystem.FileReadResult, error) {
 	m.ctrl.T.Helper()
@@ -141,6 +218,21 @@ func (mr *MockFileSystemInterfaceMockRecorder) ReadFile(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadFile", reflect.TypeOf((*MockFileSystemInterface)(nil).ReadFile), arg0)
 }
 
+// ReadFileBytes mocks base method.
+func (m *MockFileSystemInterface) ReadFileBytes(arg0 string) (*filesystem.FileReadBytesResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadFileBytes", arg0)
+	ret0, _ := ret[0].(*filesystem.FileReadBytesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadFileBytes indicates an expected call of ReadFileBytes.
+func (mr *MockFileSystemInterfaceMockRecorder) ReadFileBytes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadFileBytes", reflect.TypeOf((*MockFileSystemInterface)(nil).ReadFileBytes), arg0)
+}
+
 // ReadMultipleFiles mocks base method.
 func (m *MockFileSystemInterface) ReadMultipleFiles(arg0 []string) (map[string]string, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +248,21 @@ func (mr *MockFileSystemInterfaceMockRecorder) ReadMultipleFiles(arg0 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadMultipleFiles", reflect.TypeOf((*MockFileSystemInterface)(nil).ReadMultipleFiles), arg0)
 }
 
+// Remove mocks base method.
+func (m *MockFileSystemInterface) Remove(arg0 string, arg1 bool) (*filesystem.FileWriteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", arg0, arg1)
+	ret0, _ := ret[0].(*filesystem.FileWriteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockFileSystemInterfaceMockRecorder) Remove(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockFileSystemInterface)(nil).Remove), arg0, arg1)
+}
+
 // SearchFiles mocks base method.
 func (m *MockFileSystemInterface) SearchFiles(arg0, arg1 string, arg2 []string) (*filesystem.SearchFilesResult, error) {
 	m.ctrl.T.Helper()
@@ -171,6 +278,51 @@ func (mr *MockFileSystemInterfaceMockRecorder) SearchFiles(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFiles", reflect.TypeOf((*MockFileSystemInterface)(nil).SearchFiles), arg0, arg1, arg2)
 }
 
+// Stat mocks base method.
+func (m *MockFileSystemInterface) Stat(arg0 string) (*filesystem.FileInfoResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stat", arg0)
+	ret0, _ := ret[0].(*filesystem.FileInfoResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stat indicates an expected call of Stat.
+func (mr *MockFileSystemInterfaceMockRecorder) Stat(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stat", reflect.TypeOf((*MockFileSystemInterface)(nil).Stat), arg0)
+}
+
+// Symlink mocks base method.
+func (m *MockFileSystemInterface) Symlink(arg0, arg1 string) (*filesystem.FileWriteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Symlink", arg0, arg1)
+	ret0, _ := ret[0].(*filesystem.FileWriteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Symlink indicates an expected call of Symlink.
+func (mr *MockFileSystemInterfaceMockRecorder) Symlink(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Symlink", reflect.TypeOf((*MockFileSystemInterface)(nil).Symlink), arg0, arg1)
+}
+
+// Unpack mocks base method.
+func (m *MockFileSystemInterface) Unpack(arg0, arg1 string, arg2 *filesystem.ExtractPolicy) (*filesystem.FileWriteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unpack", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*filesystem.FileWriteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unpack indicates an expected call of Unpack.
+func (mr *MockFileSystemInterfaceMockRecorder) Unpack(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unpack", reflect.TypeOf((*MockFileSystemInterface)(nil).Unpack), arg0, arg1, arg2)
+}
+
 // WatchDirectory mocks base method.
 func (m *MockFileSystemInterface) WatchDirectory(arg0 string, arg1 func([]*filesystem.FileChangeEvent), arg2 time.Duration, arg3 <-chan struct{}) *sync.WaitGroup {
 	m.ctrl.T.Helper()
@@ -213,3 +365,18 @@ func (mr *MockFileSystemInterfaceMockRecorder) WriteFile(arg0, arg1, arg2 interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteFile", reflect.TypeOf((*MockFileSystemInterface)(nil).WriteFile), arg0, arg1, arg2)
 }
+
+// WriteFileBytes mocks base method.
+func (m *MockFileSystemInterface) WriteFileBytes(arg0 string, arg1 []byte) (*filesystem.FileWriteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteFileBytes", arg0, arg1)
+	ret0, _ := ret[0].(*filesystem.FileWriteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteFileBytes indicates an expected call of WriteFileBytes.
+func (mr *MockFileSystemInterfaceMockRecorder) WriteFileBytes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteFileBytes", reflect.TypeOf((*MockFileSystemInterface)(nil).WriteFileBytes), arg0, arg1)
+}
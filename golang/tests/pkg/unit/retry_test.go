@@ -0,0 +1,227 @@
+package agentbay_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fastRetryPolicy retries quickly so tests do not wait on real backoff delays
+func fastRetryPolicy() *agentbay.RetryPolicy {
+	return &agentbay.RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Multiplier:     2,
+	}
+}
+
+// flakyHandler fails the first failures requests with status and body, then answers with okBody
+func flakyHandler(calls *int32, failures int32, status int, body, okBody string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(calls, 1) <= failures {
+			w.WriteHeader(status)
+			w.Write([]byte(body))
+			return
+		}
+		w.Write([]byte(okBody))
+	}
+}
+
+func TestRetry_IdempotentCallRecoversFromTransientErrors(t *testing.T) {
+	var calls int32
+	ab := newTestAgentBay(t, flakyHandler(&calls, 2, http.StatusServiceUnavailable,
+		`{"Code":"ServiceUnavailable","Message":"try again","RequestId":"req-503"}`,
+		`{"RequestId":"req-ok","Data":{"Labels":"{\"env\":\"test\"}"}}`))
+	ab.SetRetryPolicy(fastRetryPolicy())
+	session := agentbay.NewSession(ab, "session-123")
+
+	result, err := session.GetLabels()
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, 2, result.RetryCount)
+	assert.Equal(t, "req-ok", result.RequestID)
+}
+
+func TestRetry_StopsAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	ab := newTestAgentBay(t, flakyHandler(&calls, 100, http.StatusInternalServerError,
+		`{"Code":"InternalError","Message":"boom","RequestId":"req-500"}`, `{}`))
+	ab.SetRetryPolicy(fastRetryPolicy())
+	session := agentbay.NewSession(ab, "session-123")
+
+	_, err := session.GetLabels()
+
+	var apiErr *agentbay.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusInternalServerError, apiErr.HTTPStatus)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetry_DoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	ab := newTestAgentBay(t, flakyHandler(&calls, 100, http.StatusBadRequest,
+		`{"Code":"InvalidParameter","Message":"bad","RequestId":"req-400"}`, `{}`))
+	ab.SetRetryPolicy(fastRetryPolicy())
+	session := agentbay.NewSession(ab, "session-123")
+
+	_, err := session.GetLabels()
+
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetry_CreateMcpSessionNotRetriedOnServerError(t *testing.T) {
+	var calls int32
+	ab := newTestAgentBay(t, flakyHandler(&calls, 100, http.StatusInternalServerError,
+		`{"Code":"InternalError","Message":"boom","RequestId":"req-500"}`, `{}`))
+	ab.SetRetryPolicy(fastRetryPolicy())
+
+	_, err := ab.Create(nil)
+
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetry_CreateMcpSessionRetriedOnThrottling(t *testing.T) {
+	var calls int32
+	ab := newTestAgentBay(t, flakyHandler(&calls, 1, http.StatusTooManyRequests,
+		`{"Code":"Throttling.User","Message":"slow down","RequestId":"req-429"}`,
+		`{"RequestId":"req-ok","Data":{"Success":true,"SessionId":"session-new"}}`))
+	ab.SetRetryPolicy(fastRetryPolicy())
+
+	result, err := ab.Create(nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Equal(t, 1, result.RetryCount)
+	assert.Equal(t, "session-new", result.Session.SessionID)
+}
+
+func TestRetry_NoRetryPolicy(t *testing.T) {
+	var calls int32
+	ab := newTestAgentBay(t, flakyHandler(&calls, 100, http.StatusServiceUnavailable,
+		`{"Code":"ServiceUnavailable","Message":"try again","RequestId":"req-503"}`, `{}`))
+	ab.SetRetryPolicy(agentbay.NoRetry())
+	session := agentbay.NewSession(ab, "session-123")
+
+	_, err := session.GetLabels()
+
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetry_CustomClassifier(t *testing.T) {
+	var calls int32
+	ab := newTestAgentBay(t, flakyHandler(&calls, 1, http.StatusBadRequest,
+		`{"Code":"IncorrectSessionStatus","Message":"not ready","RequestId":"req-400"}`,
+		`{"RequestId":"req-ok","Data":{"Labels":"{}"}}`))
+	policy := fastRetryPolicy()
+	policy.Retryable = func(err error) bool {
+		var apiErr *agentbay.APIError
+		return errors.As(err, &apiErr) && apiErr.Code == "IncorrectSessionStatus"
+	}
+	ab.SetRetryPolicy(policy)
+	session := agentbay.NewSession(ab, "session-123")
+
+	result, err := session.GetLabels()
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.RetryCount)
+}
+
+func TestRetry_ContextCancelledDuringBackoff(t *testing.T) {
+	var calls int32
+	ab := newTestAgentBay(t, flakyHandler(&calls, 100, http.StatusServiceUnavailable,
+		`{"Code":"ServiceUnavailable","Message":"try again","RequestId":"req-503"}`, `{}`))
+	ab.SetRetryPolicy(&agentbay.RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour})
+	session := agentbay.NewSession(ab, "session-123")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := session.GetLabelsWithContext(ctx)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetry_TransportTimeoutIsRetried(t *testing.T) {
+	var calls int32
+	ab := newTestAgentBayWithTimeout(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			time.Sleep(300 * time.Millisecond)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"RequestId":"req-ok","Data":{"Labels":"{}"}}`))
+	}, 100)
+	ab.SetRetryPolicy(fastRetryPolicy())
+	session := agentbay.NewSession(ab, "session-123")
+
+	result, err := session.GetLabels()
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, 2, result.RetryCount)
+
+	// The deadline of the caller is final
+	atomic.StoreInt32(&calls, 0)
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	_, err = session.GetLabelsWithContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := &agentbay.RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+	}
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.Backoff(2))
+	assert.Equal(t, 400*time.Millisecond, policy.Backoff(3))
+	assert.Equal(t, time.Second, policy.Backoff(10))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay := policy.Backoff(2)
+		assert.GreaterOrEqual(t, delay, 100*time.Millisecond)
+		assert.LessOrEqual(t, delay, 300*time.Millisecond)
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"throttling status", &agentbay.APIError{HTTPStatus: http.StatusTooManyRequests}, true},
+		{"throttling code", &agentbay.APIError{Code: "Throttling.User"}, true},
+		{"service unavailable", &agentbay.APIError{HTTPStatus: http.StatusServiceUnavailable}, true},
+		{"not implemented", &agentbay.APIError{HTTPStatus: http.StatusNotImplemented}, false},
+		{"bad request", &agentbay.APIError{HTTPStatus: http.StatusBadRequest}, false},
+		{"unauthorized", &agentbay.APIError{HTTPStatus: http.StatusUnauthorized}, false},
+		{"context cancelled", context.Canceled, false},
+		{"context deadline", context.DeadlineExceeded, false},
+		{"transport timeout", &agentbay.TimeoutError{Op: "GetLabel", Err: &agentbay.APIError{Message: "i/o timeout"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, agentbay.IsRetryableError(tt.err))
+		})
+	}
+}
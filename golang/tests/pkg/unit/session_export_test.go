@@ -0,0 +1,119 @@
+package agentbay_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createVpcSession creates a VPC session of the image code_latest
+func createVpcSession(t *testing.T, ab *agentbay.AgentBay) *agentbay.Session {
+	result, err := ab.Create(agentbay.NewCreateSessionParams().WithImageId("code_latest").WithIsVpc(true))
+	require.NoError(t, err)
+	require.Len(t, result.Session.McpTools, 1)
+	return result.Session
+}
+
+func assertSameSession(t *testing.T, expected, actual *agentbay.Session) {
+	assert.Equal(t, expected.SessionID, actual.SessionID)
+	assert.Equal(t, expected.ImageId, actual.ImageId)
+	assert.Equal(t, expected.IsVpcEnabled, actual.IsVpcEnabled)
+	assert.Equal(t, expected.NetworkInterfaceIP, actual.NetworkInterfaceIP)
+	assert.Equal(t, expected.HttpPortNumber, actual.HttpPortNumber)
+	assert.Equal(t, expected.Token, actual.Token)
+	assert.Equal(t, expected.ResourceUrl, actual.ResourceUrl)
+	assert.Equal(t, expected.McpTools, actual.McpTools)
+}
+
+func TestAgentBay_GetRestoresVpcSession(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	ab := newTestAgentBay(t, api.ServeHTTP)
+	created := createVpcSession(t, ab)
+	assert.Equal(t, "token-session-1", created.Token)
+	assert.Equal(t, "code_latest-server", created.FindServerForTool("shell"))
+
+	result, err := ab.Get(created.SessionID)
+	require.NoError(t, err)
+	assertSameSession(t, created, result.Session)
+	assert.Equal(t, []string{"code_latest"}, api.toolImages)
+
+	// Another client restores everything but the image, and cannot fetch the tools without it
+	other := newTestAgentBay(t, api.ServeHTTP)
+	result, err = other.Get(created.SessionID)
+	require.NoError(t, err)
+	assert.Empty(t, result.Session.ImageId)
+	assert.Equal(t, "token-session-1", result.Session.Token)
+	assert.Equal(t, "10.0.0.5", result.Session.NetworkInterfaceIp())
+	assert.Equal(t, "9000", result.Session.HttpPort())
+	assert.Empty(t, result.Session.McpTools)
+	assert.Equal(t, []string{"code_latest"}, api.toolImages)
+}
+
+func TestSession_ExportImportWithKey(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	ab := newTestAgentBay(t, api.ServeHTTP)
+	session := createVpcSession(t, ab)
+	key := bytes.Repeat([]byte{7}, 32)
+
+	blob, err := session.Export(key)
+	require.NoError(t, err)
+	assert.NotContains(t, string(blob), session.Token)
+	assert.NotContains(t, string(blob), session.ResourceUrl)
+	assert.NotContains(t, string(blob), ab.APIKey)
+
+	other := newTestAgentBay(t, api.ServeHTTP)
+	imported, err := other.Import(blob, key)
+	require.NoError(t, err)
+	assertSameSession(t, session, imported)
+	assert.Zero(t, api.count("GetSession"))
+	cached, ok := other.Sessions.Load(session.SessionID)
+	require.True(t, ok)
+	assert.Equal(t, session.Token, cached.(agentbay.Session).Token)
+
+	_, err = other.Import(blob, nil)
+	assert.ErrorContains(t, err, "a key is required")
+	_, err = other.Import(blob, bytes.Repeat([]byte{8}, 32))
+	assert.ErrorContains(t, err, "the key is wrong")
+	_, err = other.Import([]byte(`{"version":2,"sessionId":"session-1"}`), nil)
+	assert.ErrorContains(t, err, "unsupported exported session version 2")
+}
+
+func TestSession_ExportImportWithoutSecrets(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	ab := newTestAgentBay(t, api.ServeHTTP)
+	session := createVpcSession(t, ab)
+
+	blob, err := session.Export(nil)
+	require.NoError(t, err)
+	assert.NotContains(t, string(blob), session.Token)
+	assert.NotContains(t, string(blob), session.ResourceUrl)
+
+	// The secrets are restored from the API, the image and the tools from the export
+	other := newTestAgentBay(t, api.ServeHTTP)
+	imported, err := other.Import(blob, nil)
+	require.NoError(t, err)
+	assertSameSession(t, session, imported)
+	assert.Equal(t, 1, api.count("GetSession"))
+	assert.Equal(t, 1, api.count("ListMcpTools"))
+
+	_, err = ab.Delete(session)
+	require.NoError(t, err)
+	other = newTestAgentBay(t, api.ServeHTTP)
+	_, err = other.Import(blob, nil)
+	assert.ErrorIs(t, err, agentbay.ErrSessionNotFound)
+	_, ok := other.Sessions.Load(session.SessionID)
+	assert.False(t, ok)
+
+	// A failed import keeps the session the client already had
+	previous := agentbay.NewSession(other, session.SessionID)
+	previous.ImageId = "linux_latest"
+	other.Sessions.Store(session.SessionID, *previous)
+	_, err = other.Import(blob, nil)
+	assert.ErrorIs(t, err, agentbay.ErrSessionNotFound)
+	cached, ok := other.Sessions.Load(session.SessionID)
+	require.True(t, ok)
+	assert.Equal(t, "linux_latest", cached.(agentbay.Session).ImageId)
+}
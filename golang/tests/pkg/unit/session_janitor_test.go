@@ -0,0 +1,125 @@
+package agentbay_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seed adds a session with labels to the fake API
+func (f *fakeSessionAPI) seed(id string, labels map[string]string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sessions[id] = labels
+}
+
+func (f *fakeSessionAPI) labels(id string) map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sessions[id]
+}
+
+func expiry(d time.Duration) string {
+	return strconv.FormatInt(time.Now().Add(d).Unix(), 10)
+}
+
+func TestSessionJanitor_TrackAndScan(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	ab := newTestAgentBay(t, api.ServeHTTP)
+	janitor, err := ab.NewSessionJanitor(agentbay.SessionJanitorOptions{Owner: "worker", TTL: time.Hour, KeepaliveInterval: -1, ScanInterval: -1})
+	require.NoError(t, err)
+	defer janitor.Close()
+
+	created, err := ab.Create(nil)
+	require.NoError(t, err)
+	session := created.Session
+	_, err = session.SetLabels(map[string]string{"team": "search"})
+	require.NoError(t, err)
+
+	require.NoError(t, janitor.Track(t.Context(), session))
+	labels := api.labels(session.SessionID)
+	assert.Equal(t, "search", labels["team"])
+	assert.Equal(t, "worker", labels[agentbay.LabelSessionOwner])
+	expiresAt, err := strconv.ParseInt(labels[agentbay.LabelSessionExpiresAt], 10, 64)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Now().Add(time.Hour).Unix(), expiresAt, 5)
+
+	// Expired sessions span several pages; a tracked session is kept although its label expired
+	var expired []string
+	for i := range 120 {
+		id := fmt.Sprintf("expired-%03d", i)
+		expired = append(expired, id)
+		api.seed(id, map[string]string{agentbay.LabelSessionOwner: "worker", agentbay.LabelSessionExpiresAt: expiry(-time.Minute)})
+	}
+	api.seed("alive", map[string]string{agentbay.LabelSessionOwner: "worker", agentbay.LabelSessionExpiresAt: expiry(time.Minute)})
+	api.seed("no-expiry", map[string]string{agentbay.LabelSessionOwner: "worker"})
+	api.seed("other-owner", map[string]string{agentbay.LabelSessionOwner: "other", agentbay.LabelSessionExpiresAt: expiry(-time.Minute)})
+	labels[agentbay.LabelSessionExpiresAt] = expiry(-time.Minute)
+	api.seed(session.SessionID, labels)
+
+	result, err := janitor.Scan(t.Context())
+
+	require.NoError(t, err)
+	assert.Equal(t, 123, result.Scanned)
+	assert.Equal(t, expired, result.Released)
+	assert.Equal(t, []string{"alive", "no-expiry", "other-owner", session.SessionID}, api.live())
+}
+
+func TestSessionJanitor_Keepalive(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	ab := newTestAgentBay(t, api.ServeHTTP)
+	janitor, err := ab.NewSessionJanitor(agentbay.SessionJanitorOptions{Owner: "worker", KeepaliveInterval: -1, ScanInterval: -1})
+	require.NoError(t, err)
+	defer janitor.Close()
+
+	first, err := ab.Create(nil)
+	require.NoError(t, err)
+	second, err := ab.Create(nil)
+	require.NoError(t, err)
+	require.NoError(t, janitor.Track(t.Context(), first.Session))
+	require.NoError(t, janitor.Track(t.Context(), second.Session))
+	api.seed(first.Session.SessionID, map[string]string{agentbay.LabelSessionOwner: "worker", agentbay.LabelSessionExpiresAt: "1"})
+
+	// A session that no longer exists is untracked
+	_, err = ab.Delete(second.Session)
+	require.NoError(t, err)
+	require.NoError(t, janitor.Keepalive(t.Context()))
+
+	expiresAt, err := strconv.ParseInt(api.labels(first.Session.SessionID)[agentbay.LabelSessionExpiresAt], 10, 64)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Now().Add(agentbay.DefaultJanitorTTL).Unix(), expiresAt, 5)
+	setLabels := api.count("SetLabel")
+	require.NoError(t, janitor.Keepalive(t.Context()))
+	assert.Equal(t, setLabels+1, api.count("SetLabel"))
+
+	janitor.Untrack(first.Session)
+	require.NoError(t, janitor.Keepalive(t.Context()))
+	assert.Equal(t, setLabels+1, api.count("SetLabel"))
+}
+
+func TestSessionJanitor_Loops(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	ab := newTestAgentBay(t, api.ServeHTTP)
+	_, err := ab.NewSessionJanitor(agentbay.SessionJanitorOptions{})
+	assert.Error(t, err)
+
+	api.seed("leaked", map[string]string{agentbay.LabelSessionOwner: "worker", agentbay.LabelSessionExpiresAt: expiry(-time.Minute)})
+	janitor, err := ab.NewSessionJanitor(agentbay.SessionJanitorOptions{
+		Owner: "worker", TTL: time.Second, KeepaliveInterval: 10 * time.Millisecond, ScanInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer janitor.Close()
+
+	created, err := ab.Create(nil)
+	require.NoError(t, err)
+	require.NoError(t, janitor.Track(t.Context(), created.Session))
+	setLabels := api.count("SetLabel")
+
+	require.Eventually(t, func() bool { return api.count("SetLabel") > setLabels+2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{created.Session.SessionID}, api.live())
+}
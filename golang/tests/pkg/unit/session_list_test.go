@@ -0,0 +1,113 @@
+package agentbay_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedSessions adds n sessions labelled with labels and returns their sorted IDs
+func seedSessions(api *fakeSessionAPI, prefix string, n int, labels map[string]string) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("%s-%03d", prefix, i)
+		api.seed(ids[i], labels)
+	}
+	return ids
+}
+
+// take returns the IDs of the first n sessions from cursor
+func take(t *testing.T, ab *agentbay.AgentBay, cursor *agentbay.SessionCursor, n int) []string {
+	var ids []string
+	for info, err := range ab.ListFrom(t.Context(), cursor) {
+		require.NoError(t, err)
+		ids = append(ids, info.SessionId)
+		if len(ids) == n {
+			break
+		}
+	}
+	return ids
+}
+
+func TestAgentBay_ListAll(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	ab := newTestAgentBay(t, api.ServeHTTP)
+	expected := seedSessions(api, "demo", 250, map[string]string{"project": "demo"})
+	seedSessions(api, "other", 10, map[string]string{"project": "other"})
+
+	var ids []string
+	for info, err := range ab.ListAll(t.Context(), map[string]string{"project": "demo"}) {
+		require.NoError(t, err)
+		ids = append(ids, info.SessionId)
+	}
+	assert.Equal(t, expected, ids)
+	assert.Equal(t, 3, api.count("ListSession"))
+
+	// Pages are only requested when they are reached
+	for range ab.ListAll(t.Context(), nil) {
+		break
+	}
+	assert.Equal(t, 4, api.count("ListSession"))
+}
+
+func TestAgentBay_ListFromResumesCursor(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	ab := newTestAgentBay(t, api.ServeHTTP)
+	expected := seedSessions(api, "session", 8, map[string]string{"project": "demo"})
+
+	cursor := &agentbay.SessionCursor{Labels: map[string]string{"project": "demo"}, PageSize: 3}
+	assert.Equal(t, expected[:4], take(t, ab, cursor, 4))
+	assert.Equal(t, agentbay.SessionCursor{Labels: map[string]string{"project": "demo"}, PageSize: 3, PageToken: "3", Offset: 1}, *cursor)
+
+	encoded, err := cursor.Encode()
+	require.NoError(t, err)
+	resumed, err := agentbay.ParseSessionCursor(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, cursor, resumed)
+
+	// Stopping at the end of a page moves the cursor to the next page
+	assert.Equal(t, expected[4:6], take(t, ab, resumed, 2))
+	assert.Equal(t, "6", resumed.PageToken)
+	assert.Zero(t, resumed.Offset)
+
+	assert.Equal(t, expected[6:], take(t, ab, resumed, 10))
+	assert.True(t, resumed.Done)
+	assert.Empty(t, take(t, ab, resumed, 10))
+
+	_, err = agentbay.ParseSessionCursor("not a cursor")
+	assert.Error(t, err)
+}
+
+func TestAgentBay_ListFromError(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	failing := false
+	ab := newTestAgentBay(t, func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"RequestId":"req-list","Success":false,"Code":"InternalError","Message":"list failed"}`)
+			return
+		}
+		api.ServeHTTP(w, r)
+	})
+	expected := seedSessions(api, "session", 4, map[string]string{"project": "demo"})
+
+	cursor := &agentbay.SessionCursor{PageSize: 2}
+	assert.Equal(t, expected[:2], take(t, ab, cursor, 2))
+
+	failing = true
+	errs := 0
+	for info, err := range ab.ListFrom(t.Context(), cursor) {
+		assert.Nil(t, info)
+		assert.ErrorContains(t, err, "list failed")
+		errs++
+	}
+	assert.Equal(t, 1, errs)
+	assert.Equal(t, "2", cursor.PageToken)
+
+	failing = false
+	assert.Equal(t, expected[2:], take(t, ab, cursor, 10))
+}
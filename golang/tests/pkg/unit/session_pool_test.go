@@ -0,0 +1,310 @@
+package agentbay_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aliyun/wuying-agentbay-sdk/golang/pkg/agentbay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSessionAPI serves the session lifecycle API calls from an in-memory set of sessions
+type fakeSessionAPI struct {
+	t *testing.T
+
+	mu        sync.Mutex
+	next      int
+	sessions  map[string]map[string]string // labels by session ID
+	created   []string
+	released  []string
+	unhealthy map[string]bool
+	vpc       map[string]bool
+	calls     map[string]int
+	// toolImages holds the image IDs of the ListMcpTools calls
+	toolImages []string
+}
+
+func newFakeSessionAPI(t *testing.T) *fakeSessionAPI {
+	return &fakeSessionAPI{t: t, sessions: map[string]map[string]string{}, unhealthy: map[string]bool{}, vpc: map[string]bool{}, calls: map[string]int{}}
+}
+
+func (f *fakeSessionAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	require.NoError(f.t, r.ParseForm())
+	w.Header().Set("Content-Type", "application/json")
+	action := r.URL.Query().Get("Action")
+	sessionID := r.PostForm.Get("SessionId")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[action]++
+	_, exists := f.sessions[sessionID]
+	switch action {
+	case "CreateMcpSession":
+		f.next++
+		sessionID = fmt.Sprintf("session-%d", f.next)
+		f.sessions[sessionID] = map[string]string{}
+		f.created = append(f.created, sessionID)
+		f.vpc[sessionID] = r.PostForm.Get("VpcResource") == "true"
+		fmt.Fprintf(w, `{"RequestId":"req-create","Success":true,"Data":%s}`, f.sessionData(sessionID))
+	case "GetSession":
+		if !exists {
+			fmt.Fprint(w, `{"RequestId":"req-get","Success":false,"Code":"InvalidMcpSession.NotFound","Message":"session not found"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"RequestId":"req-get","Success":true,"Data":%s}`, f.sessionData(sessionID))
+	case "ListMcpTools":
+		image := r.PostForm.Get("ImageId")
+		f.toolImages = append(f.toolImages, image)
+		tools := fmt.Sprintf(`[{"name":"shell","description":"Run a command","server":"%s-server","tool":"shell"}]`, image)
+		fmt.Fprintf(w, `{"RequestId":"req-tools","Success":true,"Data":%q}`, tools)
+	case "GetMcpResource":
+		if !exists || f.unhealthy[sessionID] {
+			fmt.Fprint(w, `{"RequestId":"req-info","Success":false,"Code":"InvalidMcpSession.NotFound","Message":"session not found"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"RequestId":"req-info","Success":true,"Data":{"SessionId":%q}}`, sessionID)
+	case "ReleaseMcpSession":
+		delete(f.sessions, sessionID)
+		f.released = append(f.released, sessionID)
+		fmt.Fprint(w, `{"RequestId":"req-release","Success":true}`)
+	case "SetLabel", "GetLabel":
+		if !exists {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"RequestId":"req-label","Code":"InvalidMcpSession.NotFound","Message":"session not found"}`)
+			return
+		}
+		if action == "SetLabel" {
+			labels := map[string]string{}
+			require.NoError(f.t, json.Unmarshal([]byte(r.PostForm.Get("Labels")), &labels))
+			f.sessions[sessionID] = labels
+		}
+		labels, _ := json.Marshal(f.sessions[sessionID])
+		fmt.Fprintf(w, `{"RequestId":"req-label","Success":true,"Data":{"Labels":%q}}`, labels)
+	case "ListSession":
+		f.list(w, r)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// sessionData returns the data of a session in the CreateMcpSession and GetSession responses.
+// VPC sessions have a network interface, a port and a token.
+func (f *fakeSessionAPI) sessionData(sessionID string) string {
+	data := map[string]any{"SessionId": sessionID, "Success": true, "ResourceUrl": "https://resource/" + sessionID}
+	if f.vpc[sessionID] {
+		data["VpcResource"] = true
+		data["NetworkInterfaceIp"] = "10.0.0.5"
+		data["HttpPort"] = "9000"
+		data["Token"] = "token-" + sessionID
+	}
+	body, _ := json.Marshal(data)
+	return string(body)
+}
+
+// list serves a page of the sessions that have all labels of the request. NextToken is the
+// index of the first session of the page.
+func (f *fakeSessionAPI) list(w http.ResponseWriter, r *http.Request) {
+	filter := map[string]string{}
+	require.NoError(f.t, json.Unmarshal([]byte(r.PostForm.Get("Labels")), &filter))
+	var matching []string
+	for id, labels := range f.sessions {
+		matches := true
+		for key, value := range filter {
+			matches = matches && labels[key] == value
+		}
+		if matches {
+			matching = append(matching, id)
+		}
+	}
+	sort.Strings(matching)
+
+	start, _ := strconv.Atoi(r.PostForm.Get("NextToken"))
+	limit, err := strconv.Atoi(r.PostForm.Get("MaxResults"))
+	require.NoError(f.t, err)
+	end := min(start+limit, len(matching))
+	nextToken := ""
+	if end < len(matching) {
+		nextToken = strconv.Itoa(end)
+	}
+	data := []map[string]string{}
+	for _, id := range matching[start:end] {
+		data = append(data, map[string]string{"SessionId": id})
+	}
+	body, _ := json.Marshal(map[string]any{
+		"RequestId": "req-list", "Success": true, "Data": data,
+		"NextToken": nextToken, "MaxResults": limit, "TotalCount": len(matching),
+	})
+	w.Write(body)
+}
+
+// live returns the sorted IDs of the sessions that were not released
+func (f *fakeSessionAPI) live() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ids := make([]string, 0, len(f.sessions))
+	for id := range f.sessions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (f *fakeSessionAPI) count(action string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[action]
+}
+
+func TestSessionPool_AcquireReleaseAndClose(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	ab := newTestAgentBay(t, api.ServeHTTP)
+	pool := ab.NewSessionPool(nil, &agentbay.SessionPoolOptions{MinIdle: 2, MaxUses: 2, HealthCheckInterval: -1})
+	defer pool.Close()
+
+	require.Eventually(t, func() bool { return pool.Stats().Idle == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, api.count("CreateMcpSession"))
+
+	session, err := pool.Acquire(t.Context())
+	require.NoError(t, err)
+	other := map[string]string{"session-1": "session-2", "session-2": "session-1"}[session.SessionID]
+	require.NotEmpty(t, other)
+	require.Eventually(t, func() bool { return pool.Stats() == agentbay.SessionPoolStats{Idle: 2, Acquired: 1} },
+		5*time.Second, 10*time.Millisecond)
+
+	// The session goes back to the end of the queue and is deleted after its second use
+	require.NoError(t, pool.Release(session, true))
+	for _, expected := range []string{other, "session-3", session.SessionID} {
+		acquired, err := pool.Acquire(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, expected, acquired.SessionID)
+		require.NoError(t, pool.Release(acquired, expected != "session-3"))
+	}
+	assert.NotContains(t, api.live(), session.SessionID)
+	assert.NotContains(t, api.live(), "session-3")
+
+	assert.Error(t, pool.Release(session, true))
+
+	require.NoError(t, pool.Close())
+	assert.Empty(t, api.live())
+	_, err = pool.Acquire(t.Context())
+	assert.ErrorIs(t, err, agentbay.ErrPoolClosed)
+}
+
+func TestSessionPool_MaxSizeWaitsForRelease(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	ab := newTestAgentBay(t, api.ServeHTTP)
+	pool := ab.NewSessionPool(nil, &agentbay.SessionPoolOptions{MaxSize: 1, HealthCheckInterval: -1})
+	defer pool.Close()
+
+	first, err := pool.Acquire(t.Context())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	_, err = pool.Acquire(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	acquired := make(chan *agentbay.Session)
+	go func() {
+		session, err := pool.Acquire(t.Context())
+		assert.NoError(t, err)
+		acquired <- session
+	}()
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, pool.Release(first, true))
+	assert.Equal(t, first, <-acquired)
+	assert.Equal(t, 1, api.count("CreateMcpSession"))
+}
+
+func TestSessionPool_HealthCheckAndMaxAge(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	ab := newTestAgentBay(t, api.ServeHTTP)
+	pool := ab.NewSessionPool(nil, &agentbay.SessionPoolOptions{MinIdle: 2, HealthCheckInterval: 20 * time.Millisecond})
+	defer pool.Close()
+	require.Eventually(t, func() bool { return pool.Stats().Idle == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	api.mu.Lock()
+	api.unhealthy["session-1"] = true
+	api.mu.Unlock()
+
+	require.Eventually(t, func() bool {
+		live := api.live()
+		return pool.Stats().Idle == 2 && len(live) == 2 && live[0] == "session-2"
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 3, api.count("CreateMcpSession"))
+
+	aged := ab.NewSessionPool(nil, &agentbay.SessionPoolOptions{MaxAge: time.Nanosecond, HealthCheckInterval: -1})
+	defer aged.Close()
+	session, err := aged.Acquire(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, aged.Release(session, true))
+	assert.Equal(t, agentbay.SessionPoolStats{}, aged.Stats())
+	assert.NotContains(t, api.live(), session.SessionID)
+}
+
+func TestSessionPool_FailedWarmUpWakesAcquire(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	var mu sync.Mutex
+	failed := false
+	ab := newTestAgentBay(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		fail := !failed && r.URL.Query().Get("Action") == "CreateMcpSession"
+		failed = failed || fail
+		mu.Unlock()
+		if fail {
+			time.Sleep(300 * time.Millisecond)
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"RequestId":"req-create","Success":false,"Code":"InternalError","Message":"create failed"}`)
+			return
+		}
+		api.ServeHTTP(w, r)
+	})
+	pool := ab.NewSessionPool(nil, &agentbay.SessionPoolOptions{MinIdle: 1, MaxSize: 1, HealthCheckInterval: -1})
+	defer pool.Close()
+	require.Eventually(t, func() bool { return pool.Stats().Creating == 1 }, 5*time.Second, time.Millisecond)
+
+	// The failed warm-up frees the only slot, and Acquire creates the session itself
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	session, err := pool.Acquire(ctx)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.Equal(t, "session-1", session.SessionID)
+}
+
+func TestSessionPool_CancelledAcquireKeepsCreatedSession(t *testing.T) {
+	api := newFakeSessionAPI(t)
+	ab := newTestAgentBay(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("Action") == "CreateMcpSession" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		api.ServeHTTP(w, r)
+	})
+	pool := ab.NewSessionPool(nil, &agentbay.SessionPoolOptions{HealthCheckInterval: -1})
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	_, err := pool.Acquire(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The session created for the cancelled call is handed to the next one
+	assert.Equal(t, agentbay.SessionPoolStats{Idle: 1}, pool.Stats())
+	session, err := pool.Acquire(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "session-1", session.SessionID)
+	assert.Equal(t, 1, api.count("CreateMcpSession"))
+
+	require.NoError(t, pool.Close())
+	assert.Empty(t, api.live())
+}